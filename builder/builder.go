@@ -0,0 +1,190 @@
+// Package builder builds SPARQL queries and updates with a fluent API.
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/garsue/sparql/client"
+	"github.com/garsue/sparql/parser"
+)
+
+// A is the `a` keyword which means rdf:type in the predicate position.
+const A = client.PrefixedName("a")
+
+// Var is a query variable. The name has no `?` prefix.
+type Var string
+
+// String returns the variable with the `?` prefix.
+func (v Var) String() string {
+	return "?" + string(v)
+}
+
+func (v Var) expression() (string, error) {
+	if !parser.IsVarName(string(v)) {
+		return "", fmt.Errorf("invalid variable name %q", string(v))
+	}
+	return v.String(), nil
+}
+
+// Placeholder is a `$n` or `@name` placeholder filled by `client.Param`.
+type Placeholder string
+
+// Ordinal returns the `$n` placeholder.
+func Ordinal(n int) Placeholder {
+	return Placeholder("$" + strconv.Itoa(n))
+}
+
+// Named returns the `@name` placeholder.
+func Named(name string) Placeholder {
+	return Placeholder("@" + name)
+}
+
+func (p Placeholder) expression() (string, error) {
+	s := string(p)
+	if !strings.HasPrefix(s, "$") && !strings.HasPrefix(s, "@") || !parser.IsVarName(s[1:]) {
+		return "", fmt.Errorf("invalid placeholder %q", s)
+	}
+	return s, nil
+}
+
+// term serializes a value in a term position.
+//
+//   - Var, Placeholder and Expression are written as they are.
+//   - client.IRIRef is written by its `Ref`.
+//   - nil is UNDEF, which is only valid in VALUES.
+//   - Other values are serialized by `client.Param.Serialize`.
+//
+// It returns an error for malformed variable names, placeholders, blank node labels,
+// prefixed names and language tags instead of writing them.
+func term(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "UNDEF", nil
+	case Expression:
+		return t.expression()
+	case graphKeyword:
+		return "", fmt.Errorf("%s is not a term", string(t))
+	}
+	if v == A {
+		return string(A), nil
+	}
+	p := client.Param{Value: v}
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	if t, ok := v.(client.IRIRef); ok {
+		return t.Ref(), nil
+	}
+	return p.Serialize(), nil
+}
+
+// stringOf returns s or the error written as `%!(error)` for `String`. The error
+// fails to parse so that it's not sent as an empty query or update, which is valid.
+func stringOf(s string, err error) string {
+	if err != nil {
+		return "%!(" + err.Error() + ")"
+	}
+	return s
+}
+
+// kinds records the kinds of the serialized terms which are not allowed in data.
+type kinds struct {
+	bnode    bool
+	variable bool
+}
+
+func (k *kinds) merge(other kinds) {
+	k.bnode = k.bnode || other.bnode
+	k.variable = k.variable || other.variable
+}
+
+// firstError keeps the first error while serializing the parts of a query.
+type firstError struct {
+	kinds
+	err error
+}
+
+func (e *firstError) add(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// term serializes the value by `term`. It returns an empty string for an error.
+func (e *firstError) term(v interface{}) string {
+	switch v.(type) {
+	case client.BNode:
+		e.bnode = true
+	case Var:
+		e.variable = true
+	}
+	s, err := term(v)
+	e.add(err)
+	return s
+}
+
+// iri serializes the value which must be an IRI or a placeholder.
+func (e *firstError) iri(v interface{}) string {
+	switch v.(type) {
+	case client.IRIRef, Placeholder:
+		return e.term(v)
+	default:
+		e.add(fmt.Errorf("%v is not an IRI", v))
+		return ""
+	}
+}
+
+// terms serializes the values separated by spaces.
+func (e *firstError) terms(vs []interface{}) string {
+	ss := make([]string, 0, len(vs))
+	for _, v := range vs {
+		ss = append(ss, e.term(v))
+	}
+	return strings.Join(ss, " ")
+}
+
+// varName serializes the variable in the place of a variable such as `AS ?v`.
+func (e *firstError) varName(v Var) string {
+	s, err := v.expression()
+	e.add(err)
+	return s
+}
+
+func (e *firstError) expression(x Expression) string {
+	s, err := x.expression()
+	e.add(err)
+	return s
+}
+
+func (e *firstError) pattern(p Pattern) string {
+	switch p := p.(type) {
+	case patternString:
+		e.merge(p.kinds)
+	case groupPattern:
+		e.merge(p.kinds)
+	}
+	s, err := p.pattern()
+	e.add(err)
+	return s
+}
+
+// prologue is the PREFIX declarations.
+type prologue struct {
+	prefixes []string
+}
+
+func (p *prologue) addPrefix(e *firstError, name string, iri client.URI) {
+	if !parser.IsPrefix(name) {
+		e.add(fmt.Errorf("invalid prefix %q", name))
+		return
+	}
+	p.prefixes = append(p.prefixes, "PREFIX "+name+": "+iri.Ref()+"\n")
+}
+
+func (p *prologue) write(b *strings.Builder) {
+	for _, prefix := range p.prefixes {
+		b.WriteString(prefix)
+	}
+}
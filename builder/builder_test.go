@@ -0,0 +1,55 @@
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/garsue/sparql/client"
+)
+
+func Test_term(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "nil", v: nil, want: "UNDEF"},
+		{name: "var", v: Var("x"), want: "?x"},
+		{name: "ordinal", v: Ordinal(1), want: "$1"},
+		{name: "named", v: Named("s"), want: "@s"},
+		{name: "expression", v: Raw("NOW()"), want: "NOW()"},
+		{name: "uri", v: client.URI("http://example.com/a b"), want: "<http://example.com/a%20b>"},
+		{name: "prefixed name", v: client.PrefixedName("foaf:name"), want: "foaf:name"},
+		{name: "a", v: A, want: "a"},
+		{name: "bnode", v: client.BNode("b0"), want: "_:b0"},
+		{name: "string", v: "foo", want: `"""foo"""`},
+		{name: "int", v: 1, want: "1"},
+		{name: "literal", v: client.Literal{Value: "foo", LanguageTag: "en"}, want: `"""foo"""@en`},
+		{
+			name: "time",
+			v:    time.Date(2015, time.November, 19, 0, 10, 11, 0, time.UTC),
+			want: `"2015-11-19T00:10:11Z"^^xsd:dateTime`,
+		},
+		{name: "invalid bnode", v: client.BNode("x . } ; DROP ALL #"), wantErr: true},
+		{name: "invalid prefixed name", v: client.PrefixedName("foaf:name> . } ; DROP ALL #"), wantErr: true},
+		{name: "invalid language tag", v: client.Literal{Value: "foo", LanguageTag: "en . } ; DROP ALL #"}, wantErr: true},
+		{
+			name:    "invalid datatype",
+			v:       client.Literal{Value: "1", DataType: client.PrefixedName("xsd:int . } ; DROP ALL #")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := term(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("term() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("term() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package builder
+
+import (
+	"strings"
+)
+
+// Expression is a SPARQL expression. Operands of the functions building expressions
+// are serialized as terms. See `term`.
+type Expression interface {
+	expression() (string, error)
+}
+
+// expr is a serialized expression with the first error of its operands.
+type expr struct {
+	s   string
+	err error
+}
+
+func (e expr) expression() (string, error) {
+	return e.s, e.err
+}
+
+// Raw returns the SPARQL expression as it is.
+func Raw(expression string) Expression {
+	return expr{s: expression}
+}
+
+func binary(op string, a, b interface{}) Expression {
+	var e firstError
+	s := "(" + e.term(a) + " " + op + " " + e.term(b) + ")"
+	return expr{s: s, err: e.err}
+}
+
+// Eq returns `a = b`.
+func Eq(a, b interface{}) Expression { return binary("=", a, b) }
+
+// Ne returns `a != b`.
+func Ne(a, b interface{}) Expression { return binary("!=", a, b) }
+
+// Lt returns `a < b`.
+func Lt(a, b interface{}) Expression { return binary("<", a, b) }
+
+// Le returns `a <= b`.
+func Le(a, b interface{}) Expression { return binary("<=", a, b) }
+
+// Gt returns `a > b`.
+func Gt(a, b interface{}) Expression { return binary(">", a, b) }
+
+// Ge returns `a >= b`.
+func Ge(a, b interface{}) Expression { return binary(">=", a, b) }
+
+// Add returns `a + b`.
+func Add(a, b interface{}) Expression { return binary("+", a, b) }
+
+// Sub returns `a - b`.
+func Sub(a, b interface{}) Expression { return binary("-", a, b) }
+
+// Mul returns `a * b`.
+func Mul(a, b interface{}) Expression { return binary("*", a, b) }
+
+// Div returns `a / b`.
+func Div(a, b interface{}) Expression { return binary("/", a, b) }
+
+func join(op string, operands []interface{}) Expression {
+	var e firstError
+	ss := make([]string, 0, len(operands))
+	for _, o := range operands {
+		ss = append(ss, e.term(o))
+	}
+	return expr{s: "(" + strings.Join(ss, " "+op+" ") + ")", err: e.err}
+}
+
+// And returns the conjunction of the operands.
+func And(operands ...interface{}) Expression { return join("&&", operands) }
+
+// Or returns the disjunction of the operands.
+func Or(operands ...interface{}) Expression { return join("||", operands) }
+
+// Not returns the negation of the operand.
+func Not(operand interface{}) Expression {
+	return wrap("!", operand, "")
+}
+
+// wrap returns the operand between the prefix and the suffix.
+func wrap(prefix string, operand interface{}, suffix string) Expression {
+	s, err := term(operand)
+	return expr{s: prefix + s + suffix, err: err}
+}
+
+func (e *firstError) list(vs []interface{}) string {
+	ss := make([]string, 0, len(vs))
+	for _, v := range vs {
+		ss = append(ss, e.term(v))
+	}
+	return strings.Join(ss, ", ")
+}
+
+// In returns `v IN (vs...)`.
+func In(v interface{}, vs ...interface{}) Expression {
+	var e firstError
+	s := "(" + e.term(v) + " IN (" + e.list(vs) + "))"
+	return expr{s: s, err: e.err}
+}
+
+// NotIn returns `v NOT IN (vs...)`.
+func NotIn(v interface{}, vs ...interface{}) Expression {
+	var e firstError
+	s := "(" + e.term(v) + " NOT IN (" + e.list(vs) + "))"
+	return expr{s: s, err: e.err}
+}
+
+// Fn returns the function call such as `LANG(?x)` or a call of an IRI function.
+func Fn(name string, args ...interface{}) Expression {
+	var e firstError
+	s := name + "(" + e.list(args) + ")"
+	return expr{s: s, err: e.err}
+}
+
+// Bound returns `BOUND(v)`.
+func Bound(v Var) Expression { return Fn("BOUND", v) }
+
+// Regex returns `REGEX(text, pattern, flags)`. flags are optional.
+func Regex(text, pattern interface{}, flags ...string) Expression {
+	args := []interface{}{text, pattern}
+	for _, f := range flags {
+		args = append(args, f)
+	}
+	return Fn("REGEX", args...)
+}
+
+// Exists returns `EXISTS { patterns }`.
+func Exists(patterns ...Pattern) Expression {
+	var e firstError
+	s := "EXISTS " + e.group(patterns)
+	return expr{s: s, err: e.err}
+}
+
+// NotExists returns `NOT EXISTS { patterns }`.
+func NotExists(patterns ...Pattern) Expression {
+	var e firstError
+	s := "NOT EXISTS " + e.group(patterns)
+	return expr{s: s, err: e.err}
+}
+
+// Count returns `COUNT(v)`.
+func Count(v interface{}) Expression { return Fn("COUNT", v) }
+
+// CountDistinct returns `COUNT(DISTINCT v)`.
+func CountDistinct(v interface{}) Expression { return wrap("COUNT(DISTINCT ", v, ")") }
+
+// CountAll returns `COUNT(*)`.
+func CountAll() Expression { return expr{s: "COUNT(*)"} }
+
+// Sum returns `SUM(v)`.
+func Sum(v interface{}) Expression { return Fn("SUM", v) }
+
+// Avg returns `AVG(v)`.
+func Avg(v interface{}) Expression { return Fn("AVG", v) }
+
+// Min returns `MIN(v)`.
+func Min(v interface{}) Expression { return Fn("MIN", v) }
+
+// Max returns `MAX(v)`.
+func Max(v interface{}) Expression { return Fn("MAX", v) }
+
+// Sample returns `SAMPLE(v)`.
+func Sample(v interface{}) Expression { return Fn("SAMPLE", v) }
+
+// GroupConcat returns `GROUP_CONCAT(v; SEPARATOR=separator)`.
+func GroupConcat(v interface{}, separator string) Expression {
+	var e firstError
+	s := "GROUP_CONCAT(" + e.term(v) + "; SEPARATOR=" + e.term(separator) + ")"
+	return expr{s: s, err: e.err}
+}
+
+// As returns `(e AS v)` for projections and GROUP BY.
+func As(e interface{}, v Var) Expression {
+	var errs firstError
+	s := "(" + errs.term(e) + " AS " + errs.varName(v) + ")"
+	return expr{s: s, err: errs.err}
+}
+
+// Asc returns `ASC(e)` for ORDER BY.
+func Asc(e interface{}) Expression { return wrap("ASC(", e, ")") }
+
+// Desc returns `DESC(e)` for ORDER BY.
+func Desc(e interface{}) Expression { return wrap("DESC(", e, ")") }
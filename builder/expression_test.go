@@ -0,0 +1,55 @@
+package builder
+
+import (
+	"testing"
+)
+
+func TestExpression(t *testing.T) {
+	x, y := Var("x"), Var("y")
+	tests := []struct {
+		name string
+		e    Expression
+		want string
+	}{
+		{name: "Eq", e: Eq(x, 1), want: "(?x = 1)"},
+		{name: "Ne", e: Ne(x, "a"), want: `(?x != """a""")`},
+		{name: "Lt", e: Lt(x, y), want: "(?x < ?y)"},
+		{name: "Le", e: Le(x, y), want: "(?x <= ?y)"},
+		{name: "Gt", e: Gt(x, y), want: "(?x > ?y)"},
+		{name: "Ge", e: Ge(x, y), want: "(?x >= ?y)"},
+		{name: "arithmetic", e: Div(Add(x, Mul(y, 2)), Sub(x, 1)), want: "((?x + (?y * 2)) / (?x - 1))"},
+		{name: "And", e: And(Bound(x), Gt(x, 1), Lt(x, 9)), want: "(BOUND(?x) && (?x > 1) && (?x < 9))"},
+		{name: "Or", e: Or(Eq(x, 1), Eq(x, 2)), want: "((?x = 1) || (?x = 2))"},
+		{name: "Not", e: Not(Bound(x)), want: "!BOUND(?x)"},
+		{name: "In", e: In(x, 1, 2), want: "(?x IN (1, 2))"},
+		{name: "NotIn", e: NotIn(x), want: "(?x NOT IN ())"},
+		{name: "Fn", e: Fn("LANG", x), want: "LANG(?x)"},
+		{name: "Regex", e: Regex(x, "^a", "i"), want: `REGEX(?x, """^a""", """i""")`},
+		{name: "Exists", e: Exists(T(x, A, y)), want: "EXISTS { ?x a ?y . }"},
+		{name: "NotExists", e: NotExists(), want: "NOT EXISTS {}"},
+		{name: "Count", e: Count(x), want: "COUNT(?x)"},
+		{name: "CountDistinct", e: CountDistinct(x), want: "COUNT(DISTINCT ?x)"},
+		{name: "CountAll", e: CountAll(), want: "COUNT(*)"},
+		{name: "Sum", e: Sum(x), want: "SUM(?x)"},
+		{name: "Avg", e: Avg(x), want: "AVG(?x)"},
+		{name: "Min", e: Min(x), want: "MIN(?x)"},
+		{name: "Max", e: Max(x), want: "MAX(?x)"},
+		{name: "Sample", e: Sample(x), want: "SAMPLE(?x)"},
+		{name: "GroupConcat", e: GroupConcat(x, ", "), want: `GROUP_CONCAT(?x; SEPARATOR=""", """)`},
+		{name: "As", e: As(Count(x), Var("n")), want: "(COUNT(?x) AS ?n)"},
+		{name: "Asc", e: Asc(x), want: "ASC(?x)"},
+		{name: "Desc", e: Desc(x), want: "DESC(?x)"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.e.expression()
+			if err != nil {
+				t.Fatalf("expression() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
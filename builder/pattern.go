@@ -0,0 +1,148 @@
+package builder
+
+import (
+	"strings"
+)
+
+// Pattern is an element of a group graph pattern.
+type Pattern interface {
+	pattern() (string, error)
+}
+
+// patternString is a serialized pattern with the first error and the kinds of its terms.
+type patternString struct {
+	kinds
+	s   string
+	err error
+}
+
+func (p patternString) pattern() (string, error) {
+	return p.s, p.err
+}
+
+// groupPattern is a group graph pattern `{ ... }`.
+type groupPattern patternString
+
+func (g groupPattern) pattern() (string, error) {
+	return g.s, g.err
+}
+
+// group returns the group graph pattern of the patterns.
+func (e *firstError) group(patterns []Pattern) string {
+	if len(patterns) == 0 {
+		return "{}"
+	}
+	ss := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		ss = append(ss, e.pattern(p))
+	}
+	return "{ " + strings.Join(ss, " ") + " }"
+}
+
+// T returns the triple pattern. Use `A` for rdf:type.
+func T(s, p, o interface{}) Pattern {
+	var e firstError
+	t := e.term(s) + " " + e.term(p) + " " + e.term(o) + " ."
+	return patternString{s: t, err: e.err, kinds: e.kinds}
+}
+
+// Group returns the group graph pattern `{ patterns }`.
+func Group(patterns ...Pattern) Pattern {
+	var e firstError
+	s := e.group(patterns)
+	return groupPattern{s: s, err: e.err, kinds: e.kinds}
+}
+
+// keyword returns the keyword followed by the group graph pattern.
+func keyword(keyword string, patterns []Pattern) Pattern {
+	var e firstError
+	s := keyword + " " + e.group(patterns)
+	return patternString{s: s, err: e.err, kinds: e.kinds}
+}
+
+// Optional returns `OPTIONAL { patterns }`.
+func Optional(patterns ...Pattern) Pattern {
+	return keyword("OPTIONAL", patterns)
+}
+
+// Union returns `{ a } UNION { b } ...`. Alternatives which are not `Group` are
+// wrapped in groups.
+func Union(alternatives ...Pattern) Pattern {
+	var e firstError
+	ss := make([]string, 0, len(alternatives))
+	for _, a := range alternatives {
+		if g, ok := a.(groupPattern); ok {
+			ss = append(ss, e.pattern(g))
+			continue
+		}
+		ss = append(ss, e.group([]Pattern{a}))
+	}
+	return patternString{s: strings.Join(ss, " UNION "), err: e.err, kinds: e.kinds}
+}
+
+// Minus returns `MINUS { patterns }`.
+func Minus(patterns ...Pattern) Pattern {
+	return keyword("MINUS", patterns)
+}
+
+// Filter returns `FILTER (e)`.
+func Filter(e Expression) Pattern {
+	s, err := e.expression()
+	return patternString{s: "FILTER (" + s + ")", err: err}
+}
+
+// Bind returns `BIND (e AS v)`.
+func Bind(e interface{}, v Var) Pattern {
+	var errs firstError
+	s := "BIND (" + errs.term(e) + " AS " + errs.varName(v) + ")"
+	return patternString{s: s, err: errs.err}
+}
+
+// Values returns the inline data `VALUES (vars) { (row) ... }`. nil in a row is UNDEF.
+func Values(vars []Var, rows ...[]interface{}) Pattern {
+	var e firstError
+	s := e.values(vars, rows)
+	return patternString{s: s, err: e.err, kinds: e.kinds}
+}
+
+func (e *firstError) values(vars []Var, rows [][]interface{}) string {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		names = append(names, e.varName(v))
+	}
+	var b strings.Builder
+	b.WriteString("VALUES (" + strings.Join(names, " ") + ") {")
+	for _, row := range rows {
+		b.WriteString(" (" + e.terms(row) + ")")
+	}
+	b.WriteString(" }")
+	return b.String()
+}
+
+// Graph returns `GRAPH name { patterns }`.
+func Graph(name interface{}, patterns ...Pattern) Pattern {
+	var e firstError
+	s := "GRAPH " + e.term(name) + " " + e.group(patterns)
+	return patternString{s: s, err: e.err, kinds: e.kinds}
+}
+
+// Service returns `SERVICE endpoint { patterns }` for federated queries.
+func Service(endpoint interface{}, patterns ...Pattern) Pattern {
+	var e firstError
+	s := "SERVICE " + e.term(endpoint) + " " + e.group(patterns)
+	return patternString{s: s, err: e.err, kinds: e.kinds}
+}
+
+// ServiceSilent returns `SERVICE SILENT endpoint { patterns }` which ignores
+// the errors of the endpoint.
+func ServiceSilent(endpoint interface{}, patterns ...Pattern) Pattern {
+	var e firstError
+	s := "SERVICE SILENT " + e.term(endpoint) + " " + e.group(patterns)
+	return patternString{s: s, err: e.err, kinds: e.kinds}
+}
+
+// SubQuery returns the subquery `{ SELECT ... }`. The subquery must not have prefixes.
+func SubQuery(q *SelectQuery) Pattern {
+	s, err := q.Build()
+	return groupPattern{s: "{ " + s + " }", err: err}
+}
@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/garsue/sparql/client"
+)
+
+func TestPattern(t *testing.T) {
+	s, o := Var("s"), Var("o")
+	name := client.PrefixedName("foaf:name")
+	tests := []struct {
+		name string
+		p    Pattern
+		want string
+	}{
+		{name: "T", p: T(s, name, o), want: "?s foaf:name ?o ."},
+		{name: "Group", p: Group(T(s, A, o)), want: "{ ?s a ?o . }"},
+		{name: "Optional", p: Optional(T(s, name, o)), want: "OPTIONAL { ?s foaf:name ?o . }"},
+		{
+			name: "Union",
+			p:    Union(Group(T(s, name, o)), T(s, A, o)),
+			want: "{ ?s foaf:name ?o . } UNION { ?s a ?o . }",
+		},
+		{name: "Minus", p: Minus(T(s, A, o)), want: "MINUS { ?s a ?o . }"},
+		{name: "Filter", p: Filter(Eq(Fn("LANG", o), "en")), want: `FILTER ((LANG(?o) = """en"""))`},
+		{name: "Bind", p: Bind(Fn("STR", o), Var("str")), want: "BIND (STR(?o) AS ?str)"},
+		{
+			name: "Values",
+			p:    Values([]Var{s, o}, []interface{}{client.URI("http://example.com"), nil}, []interface{}{nil, 1}),
+			want: "VALUES (?s ?o) { (<http://example.com> UNDEF) (UNDEF 1) }",
+		},
+		{
+			name: "Graph",
+			p:    Graph(Var("g"), T(s, A, o)),
+			want: "GRAPH ?g { ?s a ?o . }",
+		},
+		{
+			name: "Service",
+			p:    Service(client.URI("http://example.com/sparql"), T(s, A, o)),
+			want: "SERVICE <http://example.com/sparql> { ?s a ?o . }",
+		},
+		{
+			name: "ServiceSilent",
+			p:    ServiceSilent(client.URI("http://example.com/sparql")),
+			want: "SERVICE SILENT <http://example.com/sparql> {}",
+		},
+		{
+			name: "SubQuery",
+			p:    SubQuery(Select(s).Where(T(s, A, o)).Limit(1)),
+			want: "{ SELECT ?s\nWHERE { ?s a ?o . }\nLIMIT 1 }",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.pattern()
+			if err != nil {
+				t.Fatalf("pattern() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
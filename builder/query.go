@@ -0,0 +1,424 @@
+package builder
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/garsue/sparql/client"
+)
+
+// dataset is the FROM and FROM NAMED clauses.
+type dataset struct {
+	clauses []string
+}
+
+func (d *dataset) write(b *strings.Builder) {
+	for _, c := range d.clauses {
+		b.WriteString("\n" + c)
+	}
+}
+
+// modifiers is the solution modifiers.
+type modifiers struct {
+	groupBy []string
+	having  []string
+	orderBy []string
+	limit   string
+	offset  string
+}
+
+func (m *modifiers) write(b *strings.Builder) {
+	if len(m.groupBy) > 0 {
+		b.WriteString("\nGROUP BY " + strings.Join(m.groupBy, " "))
+	}
+	if len(m.having) > 0 {
+		b.WriteString("\nHAVING " + strings.Join(m.having, " "))
+	}
+	if len(m.orderBy) > 0 {
+		b.WriteString("\nORDER BY " + strings.Join(m.orderBy, " "))
+	}
+	if m.limit != "" {
+		b.WriteString("\nLIMIT " + m.limit)
+	}
+	if m.offset != "" {
+		b.WriteString("\nOFFSET " + m.offset)
+	}
+}
+
+func (m *modifiers) addGroupBy(errs *firstError, es []interface{}) {
+	for _, e := range es {
+		m.groupBy = append(m.groupBy, errs.term(e))
+	}
+}
+
+func (m *modifiers) addHaving(errs *firstError, es []Expression) {
+	for _, e := range es {
+		m.having = append(m.having, "("+errs.expression(e)+")")
+	}
+}
+
+func (m *modifiers) addOrderBy(errs *firstError, es []interface{}) {
+	for _, e := range es {
+		m.orderBy = append(m.orderBy, errs.term(e))
+	}
+}
+
+// build returns the query serialized by serialize or the first error.
+func build(errs firstError, serialize func(e *firstError) string) (string, error) {
+	s := serialize(&errs)
+	if errs.err != nil {
+		return "", errs.err
+	}
+	return s, nil
+}
+
+// SelectQuery builds a SELECT query.
+type SelectQuery struct {
+	firstError
+	prologue
+	dataset
+	modifiers
+	modifier   string
+	projection []string
+	where      []Pattern
+	values     string
+}
+
+// Select starts a SELECT query. The projection is variables and `As` expressions.
+// It's `*` if the projection is empty.
+func Select(projection ...interface{}) *SelectQuery {
+	q := &SelectQuery{}
+	for _, p := range projection {
+		q.projection = append(q.projection, q.term(p))
+	}
+	return q
+}
+
+// Prefix adds the PREFIX declaration.
+func (q *SelectQuery) Prefix(name string, iri client.URI) *SelectQuery {
+	q.addPrefix(&q.firstError, name, iri)
+	return q
+}
+
+// Distinct makes the query SELECT DISTINCT.
+func (q *SelectQuery) Distinct() *SelectQuery {
+	q.modifier = "DISTINCT "
+	return q
+}
+
+// Reduced makes the query SELECT REDUCED.
+func (q *SelectQuery) Reduced() *SelectQuery {
+	q.modifier = "REDUCED "
+	return q
+}
+
+// From adds the FROM clause.
+func (q *SelectQuery) From(graph interface{}) *SelectQuery {
+	q.clauses = append(q.clauses, "FROM "+q.iri(graph))
+	return q
+}
+
+// FromNamed adds the FROM NAMED clause.
+func (q *SelectQuery) FromNamed(graph interface{}) *SelectQuery {
+	q.clauses = append(q.clauses, "FROM NAMED "+q.iri(graph))
+	return q
+}
+
+// Where adds the patterns to the WHERE clause.
+func (q *SelectQuery) Where(patterns ...Pattern) *SelectQuery {
+	q.where = append(q.where, patterns...)
+	return q
+}
+
+// GroupBy adds the GROUP BY conditions. They are variables or expressions.
+func (q *SelectQuery) GroupBy(conditions ...interface{}) *SelectQuery {
+	q.addGroupBy(&q.firstError, conditions)
+	return q
+}
+
+// Having adds the HAVING conditions.
+func (q *SelectQuery) Having(conditions ...Expression) *SelectQuery {
+	q.addHaving(&q.firstError, conditions)
+	return q
+}
+
+// OrderBy adds the ORDER BY conditions. Use `Asc` and `Desc` for the directions.
+func (q *SelectQuery) OrderBy(conditions ...interface{}) *SelectQuery {
+	q.addOrderBy(&q.firstError, conditions)
+	return q
+}
+
+// Limit sets the LIMIT.
+func (q *SelectQuery) Limit(n int) *SelectQuery {
+	q.limit = strconv.Itoa(n)
+	return q
+}
+
+// Offset sets the OFFSET.
+func (q *SelectQuery) Offset(n int) *SelectQuery {
+	q.offset = strconv.Itoa(n)
+	return q
+}
+
+// Values sets the inline data following the query.
+func (q *SelectQuery) Values(vars []Var, rows ...[]interface{}) *SelectQuery {
+	q.values = q.firstError.values(vars, rows)
+	return q
+}
+
+// Build returns the query or the first error of the terms such as a malformed blank
+// node label. It can be passed to `client.Client.Prepare`.
+func (q *SelectQuery) Build() (string, error) {
+	return build(q.firstError, q.serialize)
+}
+
+// String returns the query or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (q *SelectQuery) String() string {
+	return stringOf(q.Build())
+}
+
+func (q *SelectQuery) serialize(e *firstError) string {
+	var b strings.Builder
+	q.prologue.write(&b)
+	b.WriteString("SELECT " + q.modifier)
+	if len(q.projection) == 0 {
+		b.WriteString("*")
+	} else {
+		b.WriteString(strings.Join(q.projection, " "))
+	}
+	q.dataset.write(&b)
+	b.WriteString("\nWHERE " + e.group(q.where))
+	q.modifiers.write(&b)
+	if q.values != "" {
+		b.WriteString("\n" + q.values)
+	}
+	return b.String()
+}
+
+// AskQuery builds an ASK query.
+type AskQuery struct {
+	firstError
+	prologue
+	dataset
+	where []Pattern
+}
+
+// Ask starts an ASK query.
+func Ask() *AskQuery {
+	return &AskQuery{}
+}
+
+// Prefix adds the PREFIX declaration.
+func (q *AskQuery) Prefix(name string, iri client.URI) *AskQuery {
+	q.addPrefix(&q.firstError, name, iri)
+	return q
+}
+
+// From adds the FROM clause.
+func (q *AskQuery) From(graph interface{}) *AskQuery {
+	q.clauses = append(q.clauses, "FROM "+q.iri(graph))
+	return q
+}
+
+// FromNamed adds the FROM NAMED clause.
+func (q *AskQuery) FromNamed(graph interface{}) *AskQuery {
+	q.clauses = append(q.clauses, "FROM NAMED "+q.iri(graph))
+	return q
+}
+
+// Where adds the patterns to the WHERE clause.
+func (q *AskQuery) Where(patterns ...Pattern) *AskQuery {
+	q.where = append(q.where, patterns...)
+	return q
+}
+
+// Build returns the query or the first error of the terms such as a malformed blank
+// node label. It can be passed to `client.Client.Prepare`.
+func (q *AskQuery) Build() (string, error) {
+	return build(q.firstError, q.serialize)
+}
+
+// String returns the query or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (q *AskQuery) String() string {
+	return stringOf(q.Build())
+}
+
+func (q *AskQuery) serialize(e *firstError) string {
+	var b strings.Builder
+	q.prologue.write(&b)
+	b.WriteString("ASK")
+	q.dataset.write(&b)
+	b.WriteString("\nWHERE " + e.group(q.where))
+	return b.String()
+}
+
+// ConstructQuery builds a CONSTRUCT query.
+type ConstructQuery struct {
+	firstError
+	prologue
+	dataset
+	modifiers
+	template []Pattern
+	where    []Pattern
+}
+
+// Construct starts a CONSTRUCT query with the template triples.
+func Construct(template ...Pattern) *ConstructQuery {
+	return &ConstructQuery{template: template}
+}
+
+// Prefix adds the PREFIX declaration.
+func (q *ConstructQuery) Prefix(name string, iri client.URI) *ConstructQuery {
+	q.addPrefix(&q.firstError, name, iri)
+	return q
+}
+
+// From adds the FROM clause.
+func (q *ConstructQuery) From(graph interface{}) *ConstructQuery {
+	q.clauses = append(q.clauses, "FROM "+q.iri(graph))
+	return q
+}
+
+// FromNamed adds the FROM NAMED clause.
+func (q *ConstructQuery) FromNamed(graph interface{}) *ConstructQuery {
+	q.clauses = append(q.clauses, "FROM NAMED "+q.iri(graph))
+	return q
+}
+
+// Where adds the patterns to the WHERE clause.
+func (q *ConstructQuery) Where(patterns ...Pattern) *ConstructQuery {
+	q.where = append(q.where, patterns...)
+	return q
+}
+
+// OrderBy adds the ORDER BY conditions. Use `Asc` and `Desc` for the directions.
+func (q *ConstructQuery) OrderBy(conditions ...interface{}) *ConstructQuery {
+	q.addOrderBy(&q.firstError, conditions)
+	return q
+}
+
+// Limit sets the LIMIT.
+func (q *ConstructQuery) Limit(n int) *ConstructQuery {
+	q.limit = strconv.Itoa(n)
+	return q
+}
+
+// Offset sets the OFFSET.
+func (q *ConstructQuery) Offset(n int) *ConstructQuery {
+	q.offset = strconv.Itoa(n)
+	return q
+}
+
+// Build returns the query or the first error of the terms such as a malformed blank
+// node label. It can be passed to `client.Client.Prepare`.
+func (q *ConstructQuery) Build() (string, error) {
+	return build(q.firstError, q.serialize)
+}
+
+// String returns the query or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (q *ConstructQuery) String() string {
+	return stringOf(q.Build())
+}
+
+func (q *ConstructQuery) serialize(e *firstError) string {
+	var b strings.Builder
+	q.prologue.write(&b)
+	b.WriteString("CONSTRUCT " + e.group(q.template))
+	q.dataset.write(&b)
+	b.WriteString("\nWHERE " + e.group(q.where))
+	q.modifiers.write(&b)
+	return b.String()
+}
+
+// DescribeQuery builds a DESCRIBE query.
+type DescribeQuery struct {
+	firstError
+	prologue
+	dataset
+	modifiers
+	resources []string
+	where     []Pattern
+}
+
+// Describe starts a DESCRIBE query of the IRIs or variables. It's `*` if resources are empty.
+func Describe(resources ...interface{}) *DescribeQuery {
+	q := &DescribeQuery{}
+	for _, r := range resources {
+		q.resources = append(q.resources, q.term(r))
+	}
+	return q
+}
+
+// Prefix adds the PREFIX declaration.
+func (q *DescribeQuery) Prefix(name string, iri client.URI) *DescribeQuery {
+	q.addPrefix(&q.firstError, name, iri)
+	return q
+}
+
+// From adds the FROM clause.
+func (q *DescribeQuery) From(graph interface{}) *DescribeQuery {
+	q.clauses = append(q.clauses, "FROM "+q.iri(graph))
+	return q
+}
+
+// FromNamed adds the FROM NAMED clause.
+func (q *DescribeQuery) FromNamed(graph interface{}) *DescribeQuery {
+	q.clauses = append(q.clauses, "FROM NAMED "+q.iri(graph))
+	return q
+}
+
+// Where adds the patterns to the WHERE clause. The WHERE clause is omitted if it's empty.
+func (q *DescribeQuery) Where(patterns ...Pattern) *DescribeQuery {
+	q.where = append(q.where, patterns...)
+	return q
+}
+
+// OrderBy adds the ORDER BY conditions. Use `Asc` and `Desc` for the directions.
+func (q *DescribeQuery) OrderBy(conditions ...interface{}) *DescribeQuery {
+	q.addOrderBy(&q.firstError, conditions)
+	return q
+}
+
+// Limit sets the LIMIT.
+func (q *DescribeQuery) Limit(n int) *DescribeQuery {
+	q.limit = strconv.Itoa(n)
+	return q
+}
+
+// Offset sets the OFFSET.
+func (q *DescribeQuery) Offset(n int) *DescribeQuery {
+	q.offset = strconv.Itoa(n)
+	return q
+}
+
+// Build returns the query or the first error of the terms such as a malformed blank
+// node label. It can be passed to `client.Client.Prepare`.
+func (q *DescribeQuery) Build() (string, error) {
+	return build(q.firstError, q.serialize)
+}
+
+// String returns the query or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (q *DescribeQuery) String() string {
+	return stringOf(q.Build())
+}
+
+func (q *DescribeQuery) serialize(e *firstError) string {
+	var b strings.Builder
+	q.prologue.write(&b)
+	b.WriteString("DESCRIBE ")
+	if len(q.resources) == 0 {
+		b.WriteString("*")
+	} else {
+		b.WriteString(strings.Join(q.resources, " "))
+	}
+	q.dataset.write(&b)
+	if len(q.where) > 0 {
+		b.WriteString("\nWHERE " + e.group(q.where))
+	}
+	q.modifiers.write(&b)
+	return b.String()
+}
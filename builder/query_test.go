@@ -0,0 +1,185 @@
+package builder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/garsue/sparql/client"
+)
+
+func TestSelectQuery_String(t *testing.T) {
+	s, o, n := Var("s"), Var("o"), Var("n")
+	foaf := client.URI("http://xmlns.com/foaf/0.1/")
+	tests := []struct {
+		name string
+		q    *SelectQuery
+		want string
+	}{
+		{name: "empty", q: Select(), want: "SELECT *\nWHERE {}"},
+		{
+			name: "full",
+			q: Select(s, As(Count(o), n)).
+				Prefix("foaf", foaf).
+				Distinct().
+				From(client.URI("http://example.com/g")).
+				FromNamed(client.URI("http://example.com/h")).
+				Where(
+					T(s, client.PrefixedName("foaf:knows"), o),
+					Optional(T(o, client.PrefixedName("foaf:name"), Var("name"))),
+				).
+				GroupBy(s).
+				Having(Gt(Count(o), 1)).
+				OrderBy(Desc(n), s).
+				Limit(10).
+				Offset(20).
+				Values([]Var{s}, []interface{}{Ordinal(1)}),
+			want: "PREFIX foaf: <http://xmlns.com/foaf/0.1/>\n" +
+				"SELECT DISTINCT ?s (COUNT(?o) AS ?n)\n" +
+				"FROM <http://example.com/g>\n" +
+				"FROM NAMED <http://example.com/h>\n" +
+				"WHERE { ?s foaf:knows ?o . OPTIONAL { ?o foaf:name ?name . } }\n" +
+				"GROUP BY ?s\n" +
+				"HAVING ((COUNT(?o) > 1))\n" +
+				"ORDER BY DESC(?n) ?s\n" +
+				"LIMIT 10\n" +
+				"OFFSET 20\n" +
+				"VALUES (?s) { ($1) }",
+		},
+		{name: "reduced", q: Select(s).Reduced().Where(T(s, A, o)), want: "SELECT REDUCED ?s\nWHERE { ?s a ?o . }"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.String(); got != tt.want {
+				t.Errorf("SelectQuery.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskQuery_String(t *testing.T) {
+	got := Ask().
+		Prefix("ex", client.URI("http://example.com/")).
+		From(client.URI("http://example.com/g")).
+		FromNamed(client.URI("http://example.com/h")).
+		Where(T(Named("s"), A, client.PrefixedName("ex:Person"))).
+		String()
+	want := "PREFIX ex: <http://example.com/>\n" +
+		"ASK\n" +
+		"FROM <http://example.com/g>\n" +
+		"FROM NAMED <http://example.com/h>\n" +
+		"WHERE { @s a ex:Person . }"
+	if got != want {
+		t.Errorf("AskQuery.String() = %v, want %v", got, want)
+	}
+}
+
+func TestConstructQuery_String(t *testing.T) {
+	s, o := Var("s"), Var("o")
+	got := Construct(T(s, client.PrefixedName("ex:p"), o)).
+		Prefix("ex", client.URI("http://example.com/")).
+		From(client.URI("http://example.com/g")).
+		FromNamed(client.URI("http://example.com/h")).
+		Where(T(s, client.PrefixedName("ex:q"), o)).
+		OrderBy(s).
+		Limit(1).
+		Offset(2).
+		String()
+	want := "PREFIX ex: <http://example.com/>\n" +
+		"CONSTRUCT { ?s ex:p ?o . }\n" +
+		"FROM <http://example.com/g>\n" +
+		"FROM NAMED <http://example.com/h>\n" +
+		"WHERE { ?s ex:q ?o . }\n" +
+		"ORDER BY ?s\n" +
+		"LIMIT 1\n" +
+		"OFFSET 2"
+	if got != want {
+		t.Errorf("ConstructQuery.String() = %v, want %v", got, want)
+	}
+}
+
+func TestDescribeQuery_String(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *DescribeQuery
+		want string
+	}{
+		{name: "all", q: Describe(), want: "DESCRIBE *"},
+		{
+			name: "resources",
+			q:    Describe(client.URI("http://example.com/a"), Var("x")).From(client.URI("http://example.com/g")).Limit(1),
+			want: "DESCRIBE <http://example.com/a> ?x\nFROM <http://example.com/g>\nLIMIT 1",
+		},
+		{
+			name: "where",
+			q: Describe(Var("x")).
+				Prefix("ex", client.URI("http://example.com/")).
+				FromNamed(client.URI("http://example.com/h")).
+				Where(T(Var("x"), A, client.PrefixedName("ex:Person"))).
+				OrderBy(Var("x")).
+				Offset(1),
+			want: "PREFIX ex: <http://example.com/>\n" +
+				"DESCRIBE ?x\nFROM NAMED <http://example.com/h>\nWHERE { ?x a ex:Person . }\nORDER BY ?x\nOFFSET 1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.String(); got != tt.want {
+				t.Errorf("DescribeQuery.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectQuery_Build(t *testing.T) {
+	s, o := Var("s"), Var("o")
+	q := Select(s).Where(T(s, A, o), Filter(Eq(o, client.BNode("x . } ; DROP ALL #"))))
+	if got, err := q.Build(); err == nil || got != "" {
+		t.Errorf("SelectQuery.Build() = %v, %v, want an error", got, err)
+	}
+	if got, err := Select(Var("x } ; DROP ALL ; #")).Where(T(s, A, o)).Build(); err == nil || got != "" {
+		t.Errorf("SelectQuery.Build() with an invalid variable = %v, %v, want an error", got, err)
+	}
+	prefixed := Select(s).Prefix("ex x", client.URI("http://example.com/")).Where(T(s, A, o))
+	if got, err := prefixed.Build(); err == nil || got != "" {
+		t.Errorf("SelectQuery.Build() with an invalid prefix = %v, %v, want an error", got, err)
+	}
+	if got, err := Select(s).From(Default).Where(T(s, A, o)).Build(); err == nil || got != "" {
+		t.Errorf("SelectQuery.Build() with FROM DEFAULT = %v, %v, want an error", got, err)
+	}
+	if got, err := Select(s).Where(T(s, A, o)).Build(); err != nil || got != "SELECT ?s\nWHERE { ?s a ?o . }" {
+		t.Errorf("SelectQuery.Build() = %v, %v", got, err)
+	}
+}
+
+func TestSelectQuery_Prepare(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		_, _ = w.Write([]byte(`<sparql><head></head><results></results></sparql>`))
+	}))
+	defer server.Close()
+	c, err := client.New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := Select(Var("o")).Where(T(Ordinal(1), client.PrefixedName("rdfs:label"), Var("o"))).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt := c.Prepare(q)
+	if got := stmt.NumInput(); got != 1 {
+		t.Errorf("Statement.NumInput() = %v, want 1", got)
+	}
+	result, err := stmt.Query(context.Background(), client.Param{Ordinal: 1, Value: client.URI("http://example.com/a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	if want := "SELECT ?o\nWHERE { <http://example.com/a> rdfs:label ?o . }"; query != want {
+		t.Errorf("query = %v, want %v", query, want)
+	}
+}
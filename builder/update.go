@@ -0,0 +1,309 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/garsue/sparql/client"
+)
+
+// Operation is a SPARQL Update operation. Operations with a `Build() (string, error)`
+// method such as the ones of this package report the errors of their terms by it.
+type Operation interface {
+	String() string
+}
+
+// builder is an operation which reports the errors of its terms.
+type builder interface {
+	Build() (string, error)
+}
+
+// UpdateRequest is a sequence of update operations with PREFIX declarations.
+type UpdateRequest struct {
+	firstError
+	prologue
+	operations []Operation
+}
+
+// Request starts an update request of the operations.
+func Request(operations ...Operation) *UpdateRequest {
+	return &UpdateRequest{operations: operations}
+}
+
+// Prefix adds the PREFIX declaration.
+func (r *UpdateRequest) Prefix(name string, iri client.URI) *UpdateRequest {
+	r.addPrefix(&r.firstError, name, iri)
+	return r
+}
+
+// Then adds the operations.
+func (r *UpdateRequest) Then(operations ...Operation) *UpdateRequest {
+	r.operations = append(r.operations, operations...)
+	return r
+}
+
+// Build returns the update request or the first error of the operations such as
+// a malformed blank node label. It can be passed to `client.Client.Update`.
+func (r *UpdateRequest) Build() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	var b strings.Builder
+	r.prologue.write(&b)
+	ss := make([]string, 0, len(r.operations))
+	for _, o := range r.operations {
+		ob, ok := o.(builder)
+		if !ok {
+			ss = append(ss, o.String())
+			continue
+		}
+		s, err := ob.Build()
+		if err != nil {
+			return "", err
+		}
+		ss = append(ss, s)
+	}
+	b.WriteString(strings.Join(ss, " ;\n"))
+	return b.String(), nil
+}
+
+// String returns the update request or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (r *UpdateRequest) String() string {
+	return stringOf(r.Build())
+}
+
+type dataOperation patternString
+
+// Build returns the operation or the first error of the terms.
+func (d dataOperation) Build() (string, error) {
+	if d.err != nil {
+		return "", d.err
+	}
+	return d.s, nil
+}
+
+// String returns the operation or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (d dataOperation) String() string {
+	return stringOf(d.Build())
+}
+
+// newDataOperation returns the operation of the keyword and the patterns.
+// forbidden is the kinds of the terms which are errors in the patterns.
+func newDataOperation(keyword string, patterns []Pattern, forbidden kinds) Operation {
+	var e firstError
+	s := keyword + " " + e.group(patterns)
+	if forbidden.variable && e.variable {
+		e.add(fmt.Errorf("variables in %s", keyword))
+	}
+	if forbidden.bnode && e.bnode {
+		e.add(fmt.Errorf("blank nodes in %s", keyword))
+	}
+	return dataOperation{s: s, err: e.err}
+}
+
+// InsertData returns `INSERT DATA { data }`. The data is triples made by `T` and
+// `Graph` patterns of them. Terms must not be variables.
+func InsertData(data ...Pattern) Operation {
+	return newDataOperation("INSERT DATA", data, kinds{variable: true})
+}
+
+// DeleteData returns `DELETE DATA { data }`. The data is triples made by `T` and
+// `Graph` patterns of them. Terms must not be variables nor blank nodes.
+func DeleteData(data ...Pattern) Operation {
+	return newDataOperation("DELETE DATA", data, kinds{variable: true, bnode: true})
+}
+
+// DeleteWhere returns `DELETE WHERE { patterns }`. The patterns are triples made by
+// `T` and `Graph` patterns of them.
+func DeleteWhere(patterns ...Pattern) Operation {
+	return newDataOperation("DELETE WHERE", patterns, kinds{})
+}
+
+// ModifyOperation builds a `WITH ... DELETE ... INSERT ... WHERE ...` operation.
+type ModifyOperation struct {
+	firstError
+	with   string
+	delete []Pattern
+	insert []Pattern
+	using  []string
+	where  []Pattern
+}
+
+// Modify starts a DELETE/INSERT operation.
+func Modify() *ModifyOperation {
+	return &ModifyOperation{}
+}
+
+// With sets the graph which the templates and the WHERE clause default to.
+func (m *ModifyOperation) With(graph interface{}) *ModifyOperation {
+	m.with = "WITH " + m.iri(graph) + "\n"
+	return m
+}
+
+// Delete adds the templates of the triples to delete.
+func (m *ModifyOperation) Delete(template ...Pattern) *ModifyOperation {
+	m.delete = append(m.delete, template...)
+	return m
+}
+
+// Insert adds the templates of the triples to insert.
+func (m *ModifyOperation) Insert(template ...Pattern) *ModifyOperation {
+	m.insert = append(m.insert, template...)
+	return m
+}
+
+// Using adds the USING clause.
+func (m *ModifyOperation) Using(graph interface{}) *ModifyOperation {
+	m.using = append(m.using, "\nUSING "+m.iri(graph))
+	return m
+}
+
+// UsingNamed adds the USING NAMED clause.
+func (m *ModifyOperation) UsingNamed(graph interface{}) *ModifyOperation {
+	m.using = append(m.using, "\nUSING NAMED "+m.iri(graph))
+	return m
+}
+
+// Where adds the patterns to the WHERE clause.
+func (m *ModifyOperation) Where(patterns ...Pattern) *ModifyOperation {
+	m.where = append(m.where, patterns...)
+	return m
+}
+
+// Build returns the operation or the first error of the terms.
+func (m *ModifyOperation) Build() (string, error) {
+	return build(m.firstError, m.serialize)
+}
+
+// String returns the operation or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (m *ModifyOperation) String() string {
+	return stringOf(m.Build())
+}
+
+func (m *ModifyOperation) serialize(e *firstError) string {
+	var b strings.Builder
+	b.WriteString(m.with)
+	var clauses []string
+	if len(m.delete) > 0 {
+		clauses = append(clauses, "DELETE "+e.group(m.delete))
+	}
+	if len(m.insert) > 0 {
+		clauses = append(clauses, "INSERT "+e.group(m.insert))
+	}
+	b.WriteString(strings.Join(clauses, "\n"))
+	for _, u := range m.using {
+		b.WriteString(u)
+	}
+	b.WriteString("\nWHERE " + e.group(m.where))
+	return b.String()
+}
+
+// graphKeyword is a keyword in the place of a graph.
+type graphKeyword string
+
+const (
+	// Default is the default graph.
+	Default = graphKeyword("DEFAULT")
+	// NamedGraphs is all named graphs. It's only for `DropGraph` and `ClearGraph`.
+	NamedGraphs = graphKeyword("NAMED")
+	// AllGraphs is the default graph and all named graphs. It's only for `DropGraph` and `ClearGraph`.
+	AllGraphs = graphKeyword("ALL")
+)
+
+// graphRef returns `GRAPH iri` or the keyword.
+func (e *firstError) graphRef(graph interface{}) string {
+	if k, ok := graph.(graphKeyword); ok {
+		return string(k)
+	}
+	return "GRAPH " + e.iri(graph)
+}
+
+// graphOrDefault returns the IRI or `DEFAULT`.
+func (e *firstError) graphOrDefault(graph interface{}) string {
+	if graph == Default {
+		return string(Default)
+	}
+	return e.iri(graph)
+}
+
+// GraphOperation is a graph management operation.
+type GraphOperation struct {
+	firstError
+	keyword string
+	silent  bool
+	target  string
+}
+
+func newGraphOperation(keyword string, target func(e *firstError) string) *GraphOperation {
+	g := &GraphOperation{keyword: keyword}
+	g.target = target(&g.firstError)
+	return g
+}
+
+// Silent makes the operation ignore the errors.
+func (g *GraphOperation) Silent() *GraphOperation {
+	g.silent = true
+	return g
+}
+
+// Build returns the operation or the first error of the graphs.
+func (g *GraphOperation) Build() (string, error) {
+	if g.err != nil {
+		return "", g.err
+	}
+	if g.silent {
+		return g.keyword + " SILENT " + g.target, nil
+	}
+	return g.keyword + " " + g.target, nil
+}
+
+// String returns the operation or the error as `%!(error)`, which fails to parse.
+// Use `Build` to send it.
+func (g *GraphOperation) String() string {
+	return stringOf(g.Build())
+}
+
+// CreateGraph returns `CREATE GRAPH graph`.
+func CreateGraph(graph interface{}) *GraphOperation {
+	return newGraphOperation("CREATE", func(e *firstError) string {
+		return "GRAPH " + e.iri(graph)
+	})
+}
+
+// DropGraph returns `DROP` of the graph, `Default`, `NamedGraphs` or `AllGraphs`.
+func DropGraph(graph interface{}) *GraphOperation {
+	return newGraphOperation("DROP", func(e *firstError) string {
+		return e.graphRef(graph)
+	})
+}
+
+// ClearGraph returns `CLEAR` of the graph, `Default`, `NamedGraphs` or `AllGraphs`.
+func ClearGraph(graph interface{}) *GraphOperation {
+	return newGraphOperation("CLEAR", func(e *firstError) string {
+		return e.graphRef(graph)
+	})
+}
+
+// CopyGraph returns `COPY from TO to`. The graphs are IRIs or `Default`.
+func CopyGraph(from, to interface{}) *GraphOperation {
+	return newGraphOperation("COPY", func(e *firstError) string {
+		return e.graphOrDefault(from) + " TO " + e.graphOrDefault(to)
+	})
+}
+
+// MoveGraph returns `MOVE from TO to`. The graphs are IRIs or `Default`.
+func MoveGraph(from, to interface{}) *GraphOperation {
+	return newGraphOperation("MOVE", func(e *firstError) string {
+		return e.graphOrDefault(from) + " TO " + e.graphOrDefault(to)
+	})
+}
+
+// AddGraph returns `ADD from TO to`. The graphs are IRIs or `Default`.
+func AddGraph(from, to interface{}) *GraphOperation {
+	return newGraphOperation("ADD", func(e *firstError) string {
+		return e.graphOrDefault(from) + " TO " + e.graphOrDefault(to)
+	})
+}
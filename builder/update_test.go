@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/garsue/sparql/client"
+	"github.com/garsue/sparql/parser"
+)
+
+func TestOperation_String(t *testing.T) {
+	s, o := Var("s"), Var("o")
+	a := client.URI("http://example.com/a")
+	g := client.URI("http://example.com/g")
+	h := client.URI("http://example.com/h")
+	name := client.PrefixedName("foaf:name")
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{
+			name: "InsertData",
+			op: InsertData(
+				T(a, name, client.Literal{Value: `say "hi" \o/"`, LanguageTag: "en"}),
+				Graph(g, T(client.BNode("b0"), name, `"""`)),
+			),
+			want: `INSERT DATA { <http://example.com/a> foaf:name """say \"hi\" \\o/\""""@en . ` +
+				`GRAPH <http://example.com/g> { _:b0 foaf:name """\"\"\"""" . } }`,
+		},
+		{
+			name: "DeleteData",
+			op:   DeleteData(T(a, name, client.Literal{Value: "1", DataType: client.URI("http://www.w3.org/2001/XMLSchema#integer")})),
+			want: `DELETE DATA { <http://example.com/a> foaf:name """1"""^^<http://www.w3.org/2001/XMLSchema#integer> . }`,
+		},
+		{
+			name: "DeleteWhere",
+			op:   DeleteWhere(T(a, name, o)),
+			want: "DELETE WHERE { <http://example.com/a> foaf:name ?o . }",
+		},
+		{
+			name: "Modify",
+			op: Modify().
+				With(g).
+				Delete(T(s, name, o)).
+				Insert(T(s, name, Fn("UCASE", o))).
+				Using(h).
+				UsingNamed(g).
+				Where(T(s, name, o)),
+			want: "WITH <http://example.com/g>\n" +
+				"DELETE { ?s foaf:name ?o . }\n" +
+				"INSERT { ?s foaf:name UCASE(?o) . }\n" +
+				"USING <http://example.com/h>\n" +
+				"USING NAMED <http://example.com/g>\n" +
+				"WHERE { ?s foaf:name ?o . }",
+		},
+		{
+			name: "Modify insert only",
+			op:   Modify().Insert(T(s, A, o)).Where(T(s, name, o)),
+			want: "INSERT { ?s a ?o . }\nWHERE { ?s foaf:name ?o . }",
+		},
+		{name: "CreateGraph", op: CreateGraph(g), want: "CREATE GRAPH <http://example.com/g>"},
+		{name: "CreateGraph silent", op: CreateGraph(g).Silent(), want: "CREATE SILENT GRAPH <http://example.com/g>"},
+		{name: "DropGraph", op: DropGraph(g), want: "DROP GRAPH <http://example.com/g>"},
+		{name: "DropGraph all", op: DropGraph(AllGraphs).Silent(), want: "DROP SILENT ALL"},
+		{name: "ClearGraph default", op: ClearGraph(Default), want: "CLEAR DEFAULT"},
+		{name: "ClearGraph named", op: ClearGraph(NamedGraphs), want: "CLEAR NAMED"},
+		{name: "CopyGraph", op: CopyGraph(Default, g), want: "COPY DEFAULT TO <http://example.com/g>"},
+		{name: "MoveGraph", op: MoveGraph(g, h).Silent(), want: "MOVE SILENT <http://example.com/g> TO <http://example.com/h>"},
+		{name: "AddGraph", op: AddGraph(g, Default), want: "ADD <http://example.com/g> TO DEFAULT"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.op.String(); got != tt.want {
+				t.Errorf("Operation.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRequest_String(t *testing.T) {
+	got := Request(ClearGraph(client.URI("http://example.com/g"))).
+		Prefix("foaf", client.URI("http://xmlns.com/foaf/0.1/")).
+		Then(InsertData(T(client.URI("http://example.com/a"), client.PrefixedName("foaf:name"), "a"))).
+		String()
+	want := "PREFIX foaf: <http://xmlns.com/foaf/0.1/>\n" +
+		"CLEAR GRAPH <http://example.com/g> ;\n" +
+		`INSERT DATA { <http://example.com/a> foaf:name """a""" . }`
+	if got != want {
+		t.Errorf("UpdateRequest.String() = %v, want %v", got, want)
+	}
+}
+
+// nolint: scopelint
+func TestUpdateRequest_Build(t *testing.T) {
+	a := client.URI("http://example.com/a")
+	name := client.PrefixedName("foaf:name")
+	injection := "x . } ; DROP ALL #"
+	tests := []struct {
+		name string
+		r    *UpdateRequest
+	}{
+		{name: "blank node label", r: Request(InsertData(T(client.BNode(injection), name, "a")))},
+		{name: "language tag", r: Request(InsertData(T(a, name, client.Literal{Value: "a", LanguageTag: injection})))},
+		{
+			name: "datatype",
+			r:    Request(InsertData(T(a, name, client.Literal{Value: "1", DataType: client.PrefixedName(injection)}))),
+		},
+		{name: "modify", r: Request(Modify().Insert(T(a, name, "a")).Where(T(client.BNode(injection), name, Var("o"))))},
+		{name: "graph", r: Request(ClearGraph(Default), DropGraph(client.PrefixedName(injection)))},
+		{name: "variable", r: Request(DeleteWhere(T(a, name, Var("x } ; DROP ALL ; #"))))},
+		{name: "bound variable", r: Request(Modify().Insert(T(a, name, Var("o"))).Where(Bind(1, Var("o } ; DROP ALL ; #"))))},
+		{
+			name: "values variable",
+			r: Request(Modify().Insert(T(a, name, Var("o"))).
+				Where(Values([]Var{"o) {} } ; DROP ALL ; #"}, []interface{}{1}))),
+		},
+		{name: "placeholder", r: Request(DeleteWhere(T(a, name, Named("x } ; DROP ALL ; #"))))},
+		{name: "prefix", r: Request(ClearGraph(Default)).Prefix("ex x", client.URI("http://example.com/"))},
+		{name: "create default", r: Request(CreateGraph(Default))},
+		{name: "drop literal", r: Request(DropGraph("http://example.com/g"))},
+		{name: "copy to all", r: Request(CopyGraph(Default, AllGraphs))},
+		{name: "with default", r: Request(Modify().With(Default).Insert(T(a, name, "a")).Where())},
+		{name: "using default", r: Request(Modify().Insert(T(a, name, "a")).Using(Default).Where())},
+		{name: "using named default", r: Request(Modify().Insert(T(a, name, "a")).UsingNamed(Default).Where())},
+		{name: "default as a term", r: Request(InsertData(T(a, name, Default)))},
+		{name: "insert variable", r: Request(InsertData(T(a, name, Var("o"))))},
+		{name: "insert variable graph", r: Request(InsertData(Graph(Var("g"), T(a, name, "a"))))},
+		{name: "delete blank node", r: Request(DeleteData(T(client.BNode("b0"), name, "a")))},
+		{name: "delete blank node in graph", r: Request(DeleteData(Graph(a, T(a, name, client.BNode("b0")))))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.r.Build(); err == nil || got != "" {
+				t.Errorf("UpdateRequest.Build() = %v, %v, want an error", got, err)
+			}
+			if _, err := parser.Parse(tt.r.String()); err == nil {
+				t.Errorf("UpdateRequest.String() = %v, want an unparsable request", tt.r.String())
+			}
+		})
+	}
+}
+
+func TestUpdateRequest_Update(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	c, err := client.New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update, err := Request(InsertData(T(Ordinal(1), client.PrefixedName("rdfs:label"), Ordinal(2)))).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Update(context.Background(), update,
+		client.Param{Ordinal: 1, Value: client.URI("http://example.com/a")},
+		client.Param{Ordinal: 2, Value: `\"`},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT DATA { <http://example.com/a> rdfs:label """\\\"""" . }`; body != want {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+}
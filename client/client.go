@@ -8,12 +8,42 @@ import (
 
 // Client queries to its SPARQL endpoint.
 type Client struct {
-	HTTPClient   http.Client
-	Endpoint     string
-	prefixes     map[string]URI
-	resultParser ResultParser
+	HTTPClient http.Client
+	Endpoint   string
+	// UpdateEndpoint receives SPARQL Update requests.
+	// Endpoint is used instead if it is empty.
+	UpdateEndpoint string
+	prefixes       map[string]URI
+	resultParser   ResultParser
+	graphParsers   []GraphParser
+	quadParsers    []QuadParser
+	queryMethod    QueryMethod
+	urlLength      int
+	username       string
+	password       string
+	validate       bool
+	errorDecoders  []ErrorDecoder
+	retryPolicy    *RetryPolicy
 }
 
+// QueryMethod is the way to send queries defined in SPARQL 1.1 Protocol.
+type QueryMethod int
+
+const (
+	// QueryGet sends queries via GET. It's the default.
+	QueryGet QueryMethod = iota
+	// QueryPostForm sends queries via POST with an URL-encoded body.
+	QueryPostForm
+	// QueryPostDirect sends queries via POST with an `application/sparql-query` body.
+	QueryPostDirect
+	// QueryAuto sends queries via GET, or via POST with an URL-encoded body
+	// if the URL is longer than the max URL length.
+	QueryAuto
+)
+
+// DefaultMaxURLLength is the max URL length used by QueryAuto.
+const DefaultMaxURLLength = 2048
+
 // Option sets an option to the SPARQL client.
 type Option func(*Client) error
 
@@ -24,6 +54,33 @@ func WithResultParser(resultParser ResultParser) Option {
 	}
 }
 
+// WithGraphParsers replaces the parsers for CONSTRUCT and DESCRIBE results.
+// They are listed in order of preference.
+func WithGraphParsers(graphParsers ...GraphParser) Option {
+	return func(c *Client) error {
+		c.graphParsers = graphParsers
+		return nil
+	}
+}
+
+// WithQuadParsers replaces the parsers for CONSTRUCT and DESCRIBE results received
+// as RDF datasets by `ConstructQuads`. They are listed in order of preference.
+func WithQuadParsers(quadParsers ...QuadParser) Option {
+	return func(c *Client) error {
+		c.quadParsers = quadParsers
+		return nil
+	}
+}
+
+// WithErrorDecoders replaces the decoders of error response bodies.
+// They are tried in order until one of them recognizes the body.
+func WithErrorDecoders(decoders ...ErrorDecoder) Option {
+	return func(c *Client) error {
+		c.errorDecoders = decoders
+		return nil
+	}
+}
+
 // HTTPClient replaces default HTTP client.
 func WithHTTPClient(httpClient *http.Client) Option {
 	return func(c *Client) error {
@@ -32,6 +89,45 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithUpdateEndpoint sets the endpoint for SPARQL Update requests.
+func WithUpdateEndpoint(endpoint string) Option {
+	return func(c *Client) error {
+		c.UpdateEndpoint = endpoint
+		return nil
+	}
+}
+
+// WithQueryMethod sets the way to send queries.
+func WithQueryMethod(method QueryMethod) Option {
+	return func(c *Client) error {
+		if method < QueryGet || method > QueryAuto {
+			return fmt.Errorf("unknown query method %d", method)
+		}
+		c.queryMethod = method
+		return nil
+	}
+}
+
+// WithMaxURLLength sets the max URL length for QueryAuto.
+func WithMaxURLLength(length int) Option {
+	return func(c *Client) error {
+		if length <= 0 {
+			return fmt.Errorf("invalid max URL length %d", length)
+		}
+		c.urlLength = length
+		return nil
+	}
+}
+
+// WithBasicAuth sets credentials for HTTP Basic Authentication.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) error {
+		c.username = username
+		c.password = password
+		return nil
+	}
+}
+
 // WithPrefix sets a global PREFIX for all queries.
 func WithPrefix(prefix string, uri URI) Option {
 	return func(c *Client) error {
@@ -46,6 +142,15 @@ func New(endpoint string, opts ...Option) (*Client, error) {
 		Endpoint:     endpoint,
 		prefixes:     make(map[string]URI),
 		resultParser: NewXMLResultParser(),
+		graphParsers: []GraphParser{
+			NewTurtleParser(),
+			NewNTriplesParser(),
+		},
+		quadParsers: []QuadParser{
+			NewTriGParser(),
+			NewNQuadsParser(),
+		},
+		errorDecoders: DefaultErrorDecoders(),
 	}
 	for _, opt := range opts {
 		if err := opt(client); err != nil {
@@ -55,6 +160,21 @@ func New(endpoint string, opts ...Option) (*Client, error) {
 	return client, nil
 }
 
+// maxURLLength returns the max URL length for QueryAuto.
+func (c *Client) maxURLLength() int {
+	if c.urlLength > 0 {
+		return c.urlLength
+	}
+	return DefaultMaxURLLength
+}
+
+// setCredentials sets the credentials to the request if they are given.
+func (c *Client) setCredentials(request *http.Request) {
+	if c.username != "" || c.password != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+}
+
 // Close closes this client.
 // Actually nothing to do to close the HTTP client.
 func (c *Client) Close() error {
@@ -62,24 +182,21 @@ func (c *Client) Close() error {
 }
 
 // Ping sends a HTTP HEAD request to the endpoint.
-func (c *Client) Ping(ctx context.Context) (err error) {
+func (c *Client) Ping(ctx context.Context) error {
 	request, err := http.NewRequest(http.MethodHead, c.Endpoint, nil)
 	if err != nil {
 		return err
 	}
+	c.setCredentials(request)
 
-	resp, err := c.HTTPClient.Do(request.WithContext(ctx))
+	resp, err := c.send(request.WithContext(ctx), false, func(resp *http.Response) error {
+		if resp.StatusCode == http.StatusOK {
+			return nil
+		}
+		return c.newHTTPError("ping", c.Endpoint, "", resp)
+	})
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err2 := resp.Body.Close(); err2 != nil {
-			err = err2
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("SPARQL ping error. status code %d", resp.StatusCode)
-	}
-	return nil
+	return resp.Body.Close()
 }
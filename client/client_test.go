@@ -37,6 +37,75 @@ func TestWithHTTPClient(t *testing.T) {
 	}
 }
 
+func TestWithUpdateEndpoint(t *testing.T) {
+	endpoint := "http://example.com/update"
+	client := Client{}
+	if err := WithUpdateEndpoint(endpoint)(&client); err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := client.UpdateEndpoint, endpoint; got != want {
+		t.Errorf("WithUpdateEndpoint() = %v, want %v", got, want)
+	}
+}
+
+func TestWithQueryMethod(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := Client{}
+		if err := WithQueryMethod(QueryAuto)(&client); err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := client.queryMethod, QueryAuto; got != want {
+			t.Errorf("WithQueryMethod() = %v, want %v", got, want)
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		client := Client{}
+		if err := WithQueryMethod(QueryMethod(-1))(&client); err == nil {
+			t.Errorf("WithQueryMethod() error = %v", err)
+		}
+	})
+}
+
+func TestWithMaxURLLength(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := Client{}
+		if got, want := client.maxURLLength(), DefaultMaxURLLength; got != want {
+			t.Errorf("Client.maxURLLength() = %v, want %v", got, want)
+		}
+		if err := WithMaxURLLength(100)(&client); err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := client.maxURLLength(), 100; got != want {
+			t.Errorf("WithMaxURLLength() = %v, want %v", got, want)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		client := Client{}
+		if err := WithMaxURLLength(0)(&client); err == nil {
+			t.Errorf("WithMaxURLLength() error = %v", err)
+		}
+	})
+}
+
+func TestWithBasicAuth(t *testing.T) {
+	client := Client{}
+	if err := WithBasicAuth("foo", "bar")(&client); err != nil {
+		t.Error(err)
+		return
+	}
+	request, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.setCredentials(request)
+	if user, password, ok := request.BasicAuth(); !ok || user != "foo" || password != "bar" {
+		t.Errorf("WithBasicAuth() = %v %v %v", user, password, ok)
+	}
+}
+
 func TestWithPrefix(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		prefix := "dbpj"
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// CSVResultParser is the parser for CSV-formatted query results.
+//
+// The CSV format is lossy. Every bound value is returned as a `Literal` without
+// a datatype and a language tag, so URIs, blank nodes and typed literals cannot be
+// told apart from plain strings. An empty field is treated as an unbound variable,
+// which means empty string literals are lost too. Use `TSVResultParser` to keep
+// RDF terms.
+type CSVResultParser struct {
+}
+
+// NewCSVResultParser returns `CSVResultParser` as the implementation of `ResultParser`.
+func NewCSVResultParser() ResultParser {
+	return &CSVResultParser{}
+}
+
+// Parse parses the CSV query result stream.
+func (*CSVResultParser) Parse(r io.ReadCloser) (QueryResult, error) {
+	return DecodeCSVQueryResult(r)
+}
+
+// Format returns a format name string. It's used as a `format` request header value.
+func (*CSVResultParser) Format() string {
+	return "text/csv"
+}
+
+// errNoBoolean is returned by the formats which cannot represent ASK results.
+var errNoBoolean = errors.New("boolean results are not supported in this format")
+
+// CSVQueryResult is the implementation to decode SPARQL 1.1 Query Results CSV Format.
+// https://www.w3.org/TR/sparql11-results-csv-tsv/
+type CSVQueryResult struct {
+	r         io.ReadCloser
+	variables []string
+	reader    *csv.Reader
+}
+
+// DecodeCSVQueryResult decodes responded CSV Query Result.
+func DecodeCSVQueryResult(r io.ReadCloser) (QueryResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	variables := make([]string, 0, len(header))
+	variables = append(variables, header...)
+	return &CSVQueryResult{
+		r:         r,
+		variables: variables,
+		reader:    reader,
+	}, nil
+}
+
+// Variables returns query variables.
+func (c *CSVQueryResult) Variables() []string {
+	return c.variables
+}
+
+// Next returns the next bindings. It returns `io.EOF` after the last bindings.
+func (c *CSVQueryResult) Next() (map[string]Value, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	bindings := make(map[string]Value, len(c.variables))
+	for i, field := range record {
+		if i >= len(c.variables) || field == "" {
+			continue
+		}
+		bindings[c.variables[i]] = Literal{Value: field}
+	}
+	return bindings, nil
+}
+
+// Form always returns `parser.FormSelect`. The CSV format has no representation for ASK results.
+func (*CSVQueryResult) Form() (parser.Form, error) {
+	return parser.FormSelect, nil
+}
+
+// Boolean is not supported. The CSV format has no representation for ASK results.
+func (*CSVQueryResult) Boolean() (bool, error) {
+	return false, errNoBoolean
+}
+
+// Close closes the underlying reader.
+func (c *CSVQueryResult) Close() error {
+	return c.r.Close()
+}
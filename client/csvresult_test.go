@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVResultParser_Format(t *testing.T) {
+	if got, want := NewCSVResultParser().Format(), "text/csv"; got != want {
+		t.Errorf("CSVResultParser.Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeCSVQueryResult(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(``))
+		if _, err := DecodeCSVQueryResult(reader); err != io.EOF {
+			t.Errorf("DecodeCSVQueryResult() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader("x,y\r\n"))
+		got, err := DecodeCSVQueryResult(reader)
+		if err != nil {
+			t.Errorf("DecodeCSVQueryResult() error = %v", err)
+			return
+		}
+		if got, want := got.Variables(), []string{"x", "y"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("DecodeCSVQueryResult() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestCSVQueryResult_Next(t *testing.T) {
+	t.Run("bad CSV", func(t *testing.T) {
+		result, err := DecodeCSVQueryResult(ioutil.NopCloser(strings.NewReader(
+			"x\r\n\"foo\r\n",
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = result.Next()
+		if _, ok := err.(*csv.ParseError); !ok {
+			t.Errorf("CSVQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		result, err := DecodeCSVQueryResult(ioutil.NopCloser(strings.NewReader(
+			"x,hpage,name,age\r\n" +
+				"_:r2,http://work.example.org/bob/,\"Bob, Jr.\",30\r\n" +
+				",,Alice,\r\n",
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := result.Next()
+		if err != nil {
+			t.Errorf("CSVQueryResult.Next() error = %v", err)
+			return
+		}
+		want := map[string]Value{
+			"x":     Literal{Value: "_:r2"},
+			"hpage": Literal{Value: "http://work.example.org/bob/"},
+			"name":  Literal{Value: "Bob, Jr."},
+			"age":   Literal{Value: "30"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CSVQueryResult.Next() = %v, want %v", got, want)
+		}
+
+		got, err = result.Next()
+		if err != nil {
+			t.Errorf("CSVQueryResult.Next() error = %v", err)
+			return
+		}
+		want = map[string]Value{
+			"name": Literal{Value: "Alice"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CSVQueryResult.Next() = %v, want %v", got, want)
+		}
+
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("CSVQueryResult.Next() error = %v", err)
+		}
+	})
+}
+
+func TestCSVQueryResult_Boolean(t *testing.T) {
+	var c CSVQueryResult
+	if _, err := c.Boolean(); err == nil {
+		t.Errorf("CSVQueryResult.Boolean() error = %v", err)
+	}
+}
+
+func TestCSVQueryResult_Close(t *testing.T) {
+	c := &CSVQueryResult{
+		r: ioutil.NopCloser(strings.NewReader("")),
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("CSVQueryResult.Close() error = %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+
+// RDFLangString is the datatype of language-tagged literals. Register it to convert
+// them, whose `Literal.DataType` is nil.
+const RDFLangString = URI(rdfNS + "langString")
+
+// DecodeFunc converts a literal into a Go value. The literal has the registered datatype
+// or a language tag for `RDFLangString`.
+type DecodeFunc func(literal Literal) (interface{}, error)
+
+// EncodeFunc converts a Go value into a literal. The literal gets the registered datatype
+// unless it has a language tag. It returns false if the value is not handled by the function.
+type EncodeFunc func(v interface{}) (literal Literal, ok bool)
+
+type encoder struct {
+	dataType URI
+	encode   EncodeFunc
+}
+
+var (
+	datatypesMu sync.RWMutex
+	// encoders are the registered literal encoders in the registration order.
+	encoders []encoder
+)
+
+// RegisterDatatype registers the conversions of the literals with the datatype.
+// decode is used by `Literal.Decode` to receive values and encode is used by
+// `Param.Serialize` to send values as typed literals. Either of them may be nil.
+// It replaces the conversions already registered for the datatype including XSD ones.
+func RegisterDatatype(dataType URI, decode DecodeFunc, encode EncodeFunc) {
+	datatypesMu.Lock()
+	defer datatypesMu.Unlock()
+
+	key := string(dataType)
+	if decode != nil {
+		datatypes[key] = decode
+	} else {
+		delete(datatypes, key)
+	}
+
+	for i, e := range encoders {
+		if e.dataType == dataType {
+			encoders = append(encoders[:i:i], encoders[i+1:]...)
+			break
+		}
+	}
+	if encode != nil {
+		encoders = append(encoders, encoder{dataType: dataType, encode: encode})
+	}
+}
+
+// EncodeLiteral converts the value into a typed or language-tagged literal with the
+// encoders registered by `RegisterDatatype`. It returns false if no encoder handles the value.
+func EncodeLiteral(v interface{}) (Literal, bool) {
+	datatypesMu.RLock()
+	defer datatypesMu.RUnlock()
+	for _, e := range encoders {
+		if literal, ok := e.encode(v); ok {
+			literal.DataType = nil
+			if literal.LanguageTag == "" {
+				literal.DataType = e.dataType
+			}
+			return literal, true
+		}
+	}
+	return Literal{}, false
+}
+
+// datatypes is the registry of the literal conversions keyed by the datatype IRI.
+var datatypes = map[string]DecodeFunc{
+	xsdInteger:                          lexical(decodeInteger(64, math.MinInt64, math.MaxInt64)),
+	xsdNamespace + "long":               lexical(decodeInteger(64, math.MinInt64, math.MaxInt64)),
+	xsdNamespace + "int":                lexical(decodeInteger(32, math.MinInt64, math.MaxInt64)),
+	xsdNamespace + "short":              lexical(decodeInteger(16, math.MinInt64, math.MaxInt64)),
+	xsdNamespace + "byte":               lexical(decodeInteger(8, math.MinInt64, math.MaxInt64)),
+	xsdNamespace + "nonNegativeInteger": lexical(decodeInteger(64, 0, math.MaxInt64)),
+	xsdNamespace + "positiveInteger":    lexical(decodeInteger(64, 1, math.MaxInt64)),
+	xsdNamespace + "nonPositiveInteger": lexical(decodeInteger(64, math.MinInt64, 0)),
+	xsdNamespace + "negativeInteger":    lexical(decodeInteger(64, math.MinInt64, -1)),
+	xsdNamespace + "unsignedLong":       lexical(decodeUnsigned(64)),
+	xsdNamespace + "unsignedInt":        lexical(decodeUnsigned(32)),
+	xsdNamespace + "unsignedShort":      lexical(decodeUnsigned(16)),
+	xsdNamespace + "unsignedByte":       lexical(decodeUnsigned(8)),
+	xsdDecimal:                          lexical(decodeDecimal),
+	xsdNamespace + "float":              lexical(decodeFloat(32)),
+	xsdDouble:                           lexical(decodeFloat(64)),
+	xsdBoolean:                          lexical(decodeBoolean),
+	xsdNamespace + "dateTime":           lexical(decodeTime("2006-01-02T15:04:05.999999999")),
+	xsdNamespace + "dateTimeStamp":      lexical(decodeTime("2006-01-02T15:04:05.999999999")),
+	xsdNamespace + "date":               lexical(decodeTime("2006-01-02")),
+	xsdNamespace + "time":               lexical(decodeTime("15:04:05.999999999")),
+	xsdNamespace + "gYear":              lexical(decodeTime("2006")),
+	xsdNamespace + "duration":           lexical(decodeDuration),
+	xsdNamespace + "dayTimeDuration":    lexical(decodeDuration),
+	xsdNamespace + "base64Binary":       lexical(decodeBase64Binary),
+	xsdNamespace + "hexBinary":          lexical(decodeHexBinary),
+}
+
+// Decode converts the literal into a Go value according to its datatype.
+//
+//   - integer and its subtypes: int64 (uint64 for unsignedLong)
+//   - decimal, float and double: float64. Decimals are rounded to the nearest float64
+//     and lose the digits beyond its precision.
+//   - boolean: bool
+//   - dateTime, date, time and gYear: time.Time. UTC is used if the timezone is absent.
+//   - duration and dayTimeDuration: time.Duration. Years and months are not supported.
+//   - base64Binary and hexBinary: []byte
+//
+// Datatypes registered by `RegisterDatatype` are decoded by the registered functions.
+// Language-tagged literals are decoded by the function registered for `RDFLangString`.
+// Register xsd:decimal with a function returning `*big.Rat` or the lexical form to keep
+// decimals exact. Literals with other datatypes or no datatype return the lexical form.
+// It returns an error if the lexical form is invalid for the datatype.
+func (l Literal) Decode() (interface{}, error) {
+	dataType := string(RDFLangString)
+	if l.LanguageTag == "" {
+		if l.DataType == nil {
+			return l.Value, nil
+		}
+		dataType = datatypeIRI(l.DataType)
+	}
+	datatypesMu.RLock()
+	decode, ok := datatypes[dataType]
+	datatypesMu.RUnlock()
+	if !ok {
+		return l.Value, nil
+	}
+	v, err := decode(l)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %q as %s: %v", l.Value, dataType, err)
+	}
+	return v, nil
+}
+
+// lexical adapts the conversion of the lexical form to `DecodeFunc`.
+func lexical(decode func(lexical string) (interface{}, error)) DecodeFunc {
+	return func(literal Literal) (interface{}, error) {
+		return decode(literal.Value)
+	}
+}
+
+// datatypeIRI returns the IRI of the datatype. `xsd:` prefixed names are expanded.
+func datatypeIRI(ref IRIRef) string {
+	switch r := ref.(type) {
+	case URI:
+		return string(r)
+	case PrefixedName:
+		if strings.HasPrefix(string(r), "xsd:") {
+			return xsdNamespace + string(r[len("xsd:"):])
+		}
+		return string(r)
+	default:
+		return ref.Ref()
+	}
+}
+
+func decodeInteger(bitSize int, min, max int64) func(lexical string) (interface{}, error) {
+	return func(lexical string) (interface{}, error) {
+		n, err := strconv.ParseInt(strings.TrimSpace(lexical), 10, bitSize)
+		if err != nil {
+			return nil, err
+		}
+		if n < min || n > max {
+			return nil, errors.New("out of range")
+		}
+		return n, nil
+	}
+}
+
+func decodeUnsigned(bitSize int) func(lexical string) (interface{}, error) {
+	return func(lexical string) (interface{}, error) {
+		n, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(lexical), "+"), 10, bitSize)
+		if err != nil {
+			return nil, err
+		}
+		if bitSize == 64 {
+			return n, nil
+		}
+		return int64(n), nil
+	}
+}
+
+// trailingDotPattern matches numbers like "1." which are valid decimals in XSD.
+var trailingDotPattern = regexp.MustCompile(`^[+-]?[0-9]+\.$`)
+
+func decodeDecimal(lexical string) (interface{}, error) {
+	lexical = strings.TrimSpace(lexical)
+	if !integerPattern.MatchString(lexical) && !decimalPattern.MatchString(lexical) &&
+		!trailingDotPattern.MatchString(lexical) {
+		return nil, errors.New("invalid decimal")
+	}
+	return strconv.ParseFloat(lexical, 64)
+}
+
+func decodeFloat(bitSize int) func(lexical string) (interface{}, error) {
+	return func(lexical string) (interface{}, error) {
+		switch lexical = strings.TrimSpace(lexical); lexical {
+		case "INF", "+INF":
+			return math.Inf(1), nil
+		case "-INF":
+			return math.Inf(-1), nil
+		case "NaN":
+			return math.NaN(), nil
+		}
+		if numericDataType(lexical) == "" && !trailingDotPattern.MatchString(lexical) {
+			return nil, errors.New("invalid floating point number")
+		}
+		return strconv.ParseFloat(lexical, bitSize)
+	}
+}
+
+func decodeBoolean(lexical string) (interface{}, error) {
+	switch strings.TrimSpace(lexical) {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	default:
+		return nil, errors.New("invalid boolean")
+	}
+}
+
+// decodeTime parses the layout followed by an optional timezone.
+func decodeTime(layout string) func(lexical string) (interface{}, error) {
+	return func(lexical string) (interface{}, error) {
+		lexical = strings.TrimSpace(lexical)
+		for _, l := range []string{layout, layout + "Z07:00"} {
+			if t, err := time.ParseInLocation(l, lexical, time.UTC); err == nil {
+				return t, nil
+			}
+		}
+		return nil, errors.New("invalid format")
+	}
+}
+
+var durationPattern = regexp.MustCompile(
+	`^(-)?P(?:([0-9]+)Y)?(?:([0-9]+)M)?(?:([0-9]+)D)?(?:T(?:([0-9]+)H)?(?:([0-9]+)M)?(?:([0-9]+(?:\.[0-9]+)?)S)?)?$`,
+)
+
+// decodeDuration converts a duration without years and months into time.Duration.
+func decodeDuration(lexical string) (interface{}, error) {
+	lexical = strings.TrimSpace(lexical)
+	m := durationPattern.FindStringSubmatch(lexical)
+	if m == nil || lexical == "P" || lexical == "-P" || strings.HasSuffix(lexical, "T") {
+		return nil, errors.New("invalid duration")
+	}
+	if m[2] != "" && m[2] != "0" || m[3] != "" && m[3] != "0" {
+		return nil, errors.New("years and months are not supported")
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if m[4+i] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[4+i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[7] != "" {
+		s, err := strconv.ParseFloat(m[7], 64)
+		if err != nil {
+			return nil, err
+		}
+		d += time.Duration(s * float64(time.Second))
+	}
+	if m[1] != "" {
+		d = -d
+	}
+	return d, nil
+}
+
+func decodeBase64Binary(lexical string) (interface{}, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(lexical), ""))
+}
+
+func decodeHexBinary(lexical string) (interface{}, error) {
+	return hex.DecodeString(strings.TrimSpace(lexical))
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nolint: scopelint
+func TestLiteral_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		literal Literal
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "plain", literal: Literal{Value: "foo"}, want: "foo"},
+		{name: "language tag", literal: Literal{Value: "foo", LanguageTag: "en"}, want: "foo"},
+		{name: "unknown", literal: Literal{Value: "foo", DataType: URI("http://example.com/foo")}, want: "foo"},
+		{name: "integer", literal: Literal{Value: "-42", DataType: URI(xsdInteger)}, want: int64(-42)},
+		{name: "prefixed integer", literal: Literal{Value: "+42", DataType: PrefixedName("xsd:integer")}, want: int64(42)},
+		{name: "invalid integer", literal: Literal{Value: "4.2", DataType: URI(xsdInteger)}, wantErr: true},
+		{name: "byte", literal: Literal{Value: "127", DataType: URI(xsdNamespace + "byte")}, want: int64(127)},
+		{name: "byte overflow", literal: Literal{Value: "128", DataType: URI(xsdNamespace + "byte")}, wantErr: true},
+		{name: "positiveInteger", literal: Literal{Value: "0", DataType: URI(xsdNamespace + "positiveInteger")}, wantErr: true},
+		{name: "unsignedInt", literal: Literal{Value: "4294967295", DataType: URI(xsdNamespace + "unsignedInt")}, want: int64(4294967295)},
+		{name: "unsignedLong", literal: Literal{Value: "18446744073709551615", DataType: URI(xsdNamespace + "unsignedLong")}, want: uint64(math.MaxUint64)},
+		{name: "decimal", literal: Literal{Value: "-1.5", DataType: URI(xsdDecimal)}, want: -1.5},
+		{name: "decimal trailing dot", literal: Literal{Value: "1.", DataType: URI(xsdDecimal)}, want: 1.0},
+		{name: "decimal exponent", literal: Literal{Value: "1e3", DataType: URI(xsdDecimal)}, wantErr: true},
+		{name: "double", literal: Literal{Value: "1.5E3", DataType: URI(xsdDouble)}, want: 1500.0},
+		{name: "double INF", literal: Literal{Value: "-INF", DataType: URI(xsdDouble)}, want: math.Inf(-1)},
+		{name: "float", literal: Literal{Value: "INF", DataType: URI(xsdNamespace + "float")}, want: math.Inf(1)},
+		{name: "invalid double", literal: Literal{Value: "Infinity", DataType: URI(xsdDouble)}, wantErr: true},
+		{name: "boolean", literal: Literal{Value: "true", DataType: URI(xsdBoolean)}, want: true},
+		{name: "boolean digit", literal: Literal{Value: "0", DataType: URI(xsdBoolean)}, want: false},
+		{name: "invalid boolean", literal: Literal{Value: "yes", DataType: URI(xsdBoolean)}, wantErr: true},
+		{
+			name:    "dateTime",
+			literal: Literal{Value: "2015-11-19T00:10:11.5Z", DataType: URI(xsdNamespace + "dateTime")},
+			want:    time.Date(2015, time.November, 19, 0, 10, 11, 500000000, time.UTC),
+		},
+		{
+			name:    "date",
+			literal: Literal{Value: "2015-11-19", DataType: URI(xsdNamespace + "date")},
+			want:    time.Date(2015, time.November, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "date with timezone",
+			literal: Literal{Value: "2015-11-19+09:00", DataType: URI(xsdNamespace + "date")},
+			want:    time.Date(2015, time.November, 19, 0, 0, 0, 0, time.FixedZone("", 9*60*60)),
+		},
+		{
+			name:    "time",
+			literal: Literal{Value: "13:20:00", DataType: URI(xsdNamespace + "time")},
+			want:    time.Date(0, time.January, 1, 13, 20, 0, 0, time.UTC),
+		},
+		{
+			name:    "gYear",
+			literal: Literal{Value: "2015", DataType: URI(xsdNamespace + "gYear")},
+			want:    time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "duration",
+			literal: Literal{Value: "P1DT2H3M4.5S", DataType: URI(xsdNamespace + "duration")},
+			want:    26*time.Hour + 3*time.Minute + 4500*time.Millisecond,
+		},
+		{
+			name:    "negative duration",
+			literal: Literal{Value: "-PT1M", DataType: URI(xsdNamespace + "dayTimeDuration")},
+			want:    -time.Minute,
+		},
+		{name: "duration with months", literal: Literal{Value: "P1M", DataType: URI(xsdNamespace + "duration")}, wantErr: true},
+		{name: "empty duration", literal: Literal{Value: "PT", DataType: URI(xsdNamespace + "duration")}, wantErr: true},
+		{name: "base64Binary", literal: Literal{Value: "Zm9v", DataType: URI(xsdNamespace + "base64Binary")}, want: []byte("foo")},
+		{name: "hexBinary", literal: Literal{Value: "666F6F", DataType: URI(xsdNamespace + "hexBinary")}, want: []byte("foo")},
+		{name: "invalid hexBinary", literal: Literal{Value: "6", DataType: URI(xsdNamespace + "hexBinary")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.literal.Decode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Literal.Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Literal.Decode() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		got, err := Literal{Value: "NaN", DataType: URI(xsdDouble)}.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, ok := got.(float64); !ok || !math.IsNaN(f) {
+			t.Errorf("Literal.Decode() = %v, want NaN", got)
+		}
+	})
+}
+
+type point struct {
+	x, y float64
+}
+
+func TestRegisterDatatype(t *testing.T) {
+	const wktLiteral = URI("http://www.opengis.net/ont/geosparql#wktLiteral")
+	RegisterDatatype(wktLiteral, func(literal Literal) (interface{}, error) {
+		var p point
+		if _, err := fmt.Sscanf(literal.Value, "POINT(%g %g)", &p.x, &p.y); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}, func(v interface{}) (Literal, bool) {
+		p, ok := v.(point)
+		if !ok {
+			return Literal{}, false
+		}
+		return Literal{Value: fmt.Sprintf("POINT(%g %g)", p.x, p.y)}, true
+	})
+	defer RegisterDatatype(wktLiteral, nil, nil)
+
+	serialized := Param{Ordinal: 1, Value: point{x: 1.5, y: 2}}.Serialize()
+	if want := `"""POINT(1.5 2)"""^^<http://www.opengis.net/ont/geosparql#wktLiteral>`; serialized != want {
+		t.Errorf("Param.Serialize() = %v, want %v", serialized, want)
+	}
+	if got, want := (Param{Ordinal: 1, Value: 1}).Serialize(), "1"; got != want {
+		t.Errorf("Param.Serialize() = %v, want %v", got, want)
+	}
+
+	got, err := Literal{Value: "POINT(1.5 2)", DataType: wktLiteral}.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (point{x: 1.5, y: 2}); got != want {
+		t.Errorf("Literal.Decode() = %v, want %v", got, want)
+	}
+
+	t.Run("unregister", func(t *testing.T) {
+		RegisterDatatype(wktLiteral, nil, nil)
+		if got := (Param{Ordinal: 1, Value: "x"}).Serialize(); got != `"""x"""` {
+			t.Errorf("Param.Serialize() = %v", got)
+		}
+		got, err := Literal{Value: "POINT(1.5 2)", DataType: wktLiteral}.Decode()
+		if err != nil || got != "POINT(1.5 2)" {
+			t.Errorf("Literal.Decode() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("override XSD", func(t *testing.T) {
+		RegisterDatatype(URI(xsdBoolean), func(literal Literal) (interface{}, error) {
+			return literal.Value == "yes", nil
+		}, nil)
+		defer RegisterDatatype(URI(xsdBoolean), lexical(decodeBoolean), nil)
+		got, err := Literal{Value: "yes", DataType: URI(xsdBoolean)}.Decode()
+		if err != nil || got != true {
+			t.Errorf("Literal.Decode() = %v, %v", got, err)
+		}
+	})
+}
+
+type text struct {
+	value, lang string
+}
+
+func TestRegisterDatatype_langString(t *testing.T) {
+	RegisterDatatype(RDFLangString, func(literal Literal) (interface{}, error) {
+		return text{value: literal.Value, lang: literal.LanguageTag}, nil
+	}, func(v interface{}) (Literal, bool) {
+		x, ok := v.(text)
+		if !ok {
+			return Literal{}, false
+		}
+		return Literal{Value: x.value, LanguageTag: x.lang}, true
+	})
+	defer RegisterDatatype(RDFLangString, nil, nil)
+
+	serialized := Param{Ordinal: 1, Value: text{value: "chat", lang: "fr"}}.Serialize()
+	if want := `"""chat"""@fr`; serialized != want {
+		t.Errorf("Param.Serialize() = %v, want %v", serialized, want)
+	}
+	got, err := Literal{Value: "chat", LanguageTag: "fr"}.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (text{value: "chat", lang: "fr"}); got != want {
+		t.Errorf("Literal.Decode() = %v, want %v", got, want)
+	}
+	if got, err := (Literal{Value: "chat"}).Decode(); err != nil || got != "chat" {
+		t.Errorf("Literal.Decode() of a plain literal = %v, %v", got, err)
+	}
+}
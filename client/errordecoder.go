@@ -0,0 +1,238 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrorDetail is the error detail decoded from the response body of `HTTPError`.
+type ErrorDetail struct {
+	// Code is the vendor-specific error code such as `SP030`. It may be empty.
+	Code    string
+	Message string
+	// Line and Column are the position of the error in the query. They are 0 if unknown.
+	Line   int
+	Column int
+	// Syntax is true if the server rejected the query as malformed.
+	Syntax bool
+}
+
+// String returns the code, the position and the message.
+func (d *ErrorDetail) String() string {
+	var prefix []string
+	if d.Code != "" {
+		prefix = append(prefix, d.Code)
+	}
+	if d.Syntax {
+		prefix = append(prefix, "syntax error")
+	}
+	switch {
+	case d.Line > 0 && d.Column > 0:
+		prefix = append(prefix, fmt.Sprintf("at line %d col %d", d.Line, d.Column))
+	case d.Line > 0:
+		prefix = append(prefix, fmt.Sprintf("at line %d", d.Line))
+	}
+	if len(prefix) == 0 {
+		return d.Message
+	}
+	return strings.Join(prefix, " ") + ": " + d.Message
+}
+
+// ErrorDecoder decodes the response body of the error.
+// It returns nil if the body is not the format of the decoder.
+type ErrorDecoder func(e *HTTPError) *ErrorDetail
+
+// DefaultErrorDecoders returns the decoders used by `New` in order of trial.
+// Prepend your own to them with `WithErrorDecoders` to keep the built-in ones.
+func DefaultErrorDecoders() []ErrorDecoder {
+	return []ErrorDecoder{
+		DecodeStardogError,
+		DecodeNeptuneError,
+		DecodeVirtuosoError,
+		DecodeGraphDBError,
+		DecodeBlazegraphError,
+		DecodeFusekiError,
+		DecodeHTMLError,
+	}
+}
+
+var (
+	// positionPattern matches "line 1, column 24" of JavaCC based parsers and "line 3:" of Virtuoso.
+	positionPattern = regexp.MustCompile(`(?i)\bline (\d+)(?:,? col(?:umn)? (\d+))?`)
+	syntaxPattern   = regexp.MustCompile(`(?i:parse error|syntax error|lexical error|malformed query)|Encountered "`)
+)
+
+// newErrorDetail returns the detail with the position found in the message.
+func newErrorDetail(code, message string) *ErrorDetail {
+	d := &ErrorDetail{
+		Code:    code,
+		Message: strings.TrimSpace(message),
+		Syntax:  syntaxPattern.MatchString(message),
+	}
+	if m := positionPattern.FindStringSubmatch(message); m != nil {
+		d.Line, _ = strconv.Atoi(m[1])
+		d.Column, _ = strconv.Atoi(m[2])
+	}
+	return d
+}
+
+// summary returns the line which has the position of the error, or the first non-empty line.
+func summary(text string) string {
+	var first string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if positionPattern.MatchString(line) {
+			return line
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	return first
+}
+
+// DecodeStardogError decodes `{"message": "...", "code": "..."}` of Stardog.
+// The code is also sent in the `SD-Error-Code` header.
+func DecodeStardogError(e *HTTPError) *ErrorDetail {
+	var body struct {
+		Message *string `json:"message"`
+		Code    string  `json:"code"`
+	}
+	if json.Unmarshal(e.Body, &body) != nil || body.Message == nil {
+		return nil
+	}
+	code := e.Header.Get("SD-Error-Code")
+	if code == "" {
+		code = body.Code
+	}
+	return newErrorDetail(code, *body.Message)
+}
+
+// DecodeNeptuneError decodes `{"code": "...", "detailedMessage": "..."}` of Amazon Neptune.
+func DecodeNeptuneError(e *HTTPError) *ErrorDetail {
+	var body struct {
+		Code            string  `json:"code"`
+		DetailedMessage *string `json:"detailedMessage"`
+	}
+	if json.Unmarshal(e.Body, &body) != nil || body.DetailedMessage == nil {
+		return nil
+	}
+	d := newErrorDetail(body.Code, *body.DetailedMessage)
+	d.Syntax = d.Syntax || body.Code == "MalformedQueryException"
+	return d
+}
+
+// virtuosoPattern matches "Virtuoso 37000 Error SP030: message". 37000 is the SQL state.
+var virtuosoPattern = regexp.MustCompile(`(?m)^Virtuoso (\w+) Error (\w+): ?(.*)$`)
+
+// DecodeVirtuosoError decodes the plain text errors of Virtuoso.
+func DecodeVirtuosoError(e *HTTPError) *ErrorDetail {
+	m := virtuosoPattern.FindSubmatch(e.Body)
+	if m == nil {
+		return nil
+	}
+	d := newErrorDetail(string(m[2]), string(m[3]))
+	d.Syntax = d.Syntax || string(m[1]) == "37000"
+	return d
+}
+
+// graphDBPattern matches the error types of RDF4J, on which GraphDB is based.
+var graphDBPattern = regexp.MustCompile(
+	`^(MALFORMED QUERY|MALFORMED DATA|UNSUPPORTED QUERY LANGUAGE|UNSUPPORTED FILE FORMAT|UNKNOWN REPOSITORY): ?`,
+)
+
+// DecodeGraphDBError decodes the plain text errors of GraphDB and RDF4J servers.
+func DecodeGraphDBError(e *HTTPError) *ErrorDetail {
+	m := graphDBPattern.FindSubmatchIndex(e.Body)
+	if m == nil {
+		return nil
+	}
+	code := string(e.Body[m[2]:m[3]])
+	d := newErrorDetail(code, summary(string(e.Body[m[1]:])))
+	d.Syntax = d.Syntax || code == "MALFORMED QUERY"
+	return d
+}
+
+var (
+	blazegraphPattern = regexp.MustCompile(`^SPARQL-(?:QUERY|UPDATE): `)
+	// exceptionPattern matches a Java exception such as "org.openrdf.query.MalformedQueryException: ".
+	exceptionPattern = regexp.MustCompile(`(?:[\w$]+\.)*(\w+(?:Exception|Error)): `)
+)
+
+// DecodeBlazegraphError decodes the errors of Blazegraph. They start with the
+// query and have the stack trace. The code is the name of the innermost exception.
+func DecodeBlazegraphError(e *HTTPError) *ErrorDetail {
+	if !blazegraphPattern.Match(e.Body) {
+		return nil
+	}
+	for _, line := range strings.Split(string(e.Body), "\n") {
+		ms := exceptionPattern.FindAllStringSubmatchIndex(line, -1)
+		if ms == nil {
+			continue
+		}
+		m := ms[len(ms)-1]
+		code := line[m[2]:m[3]]
+		d := newErrorDetail(code, line[m[1]:])
+		d.Syntax = d.Syntax || code == "MalformedQueryException"
+		return d
+	}
+	return newErrorDetail("", summary(blazegraphPattern.ReplaceAllString(string(e.Body), "")))
+}
+
+// fusekiPattern matches "Error 400: Parse error: ..." of Fuseki.
+var fusekiPattern = regexp.MustCompile(`^Error \d+: ?`)
+
+// DecodeFusekiError decodes the plain text errors of Apache Jena Fuseki.
+func DecodeFusekiError(e *HTTPError) *ErrorDetail {
+	loc := fusekiPattern.FindIndex(e.Body)
+	if loc == nil {
+		return nil
+	}
+	text := string(e.Body[loc[1]:])
+	message := summary(text)
+	// Keep the kind of the error such as "Parse error:" on the first line.
+	if first := summary(strings.SplitN(text, "\n", 2)[0]); first != "" && first != message &&
+		strings.HasSuffix(first, ":") {
+		message = first + " " + message
+	}
+	return newErrorDetail("", message)
+}
+
+var (
+	htmlPattern    = regexp.MustCompile(`(?i)^\s*(?:<!DOCTYPE html|<html)`)
+	htmlSkip       = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+	htmlTitle      = regexp.MustCompile(`(?is)<title>(.*?)</title>`)
+	htmlTag        = regexp.MustCompile(`<[^>]*>`)
+	htmlWhitespace = regexp.MustCompile(`[ \t\r]+`)
+)
+
+// DecodeHTMLError extracts the text from HTML error pages such as ones of
+// servlet containers. It's tried last by default.
+func DecodeHTMLError(e *HTTPError) *ErrorDetail {
+	if !htmlPattern.Match(e.Body) && !strings.HasPrefix(e.Header.Get("Content-Type"), "text/html") {
+		return nil
+	}
+	text := htmlText(string(e.Body))
+	if m := htmlTitle.FindStringSubmatch(string(e.Body)); m != nil && !positionPattern.MatchString(text) {
+		// The title is often the most informative part without the position.
+		if title := strings.TrimSpace(html.UnescapeString(m[1])); title != "" {
+			text = title + "\n" + text
+		}
+	}
+	return newErrorDetail("", summary(text))
+}
+
+// htmlText returns the text of the HTML with a line for each element.
+func htmlText(s string) string {
+	s = htmlSkip.ReplaceAllString(s, "")
+	s = htmlTag.ReplaceAllString(s, "\n")
+	s = html.UnescapeString(s)
+	return htmlWhitespace.ReplaceAllString(s, " ")
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// nolint: scopelint
+func TestErrorDetail_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail ErrorDetail
+		want   string
+	}{
+		{
+			name:   "all",
+			detail: ErrorDetail{Code: "SP030", Message: "bad", Line: 3, Column: 14, Syntax: true},
+			want:   "SP030 syntax error at line 3 col 14: bad",
+		},
+		{name: "line", detail: ErrorDetail{Message: "bad", Line: 3}, want: "at line 3: bad"},
+		{name: "code", detail: ErrorDetail{Code: "QE0PE2", Message: "bad"}, want: "QE0PE2: bad"},
+		{name: "message", detail: ErrorDetail{Message: "bad"}, want: "bad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.detail.String(); got != tt.want {
+				t.Errorf("ErrorDetail.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestDefaultErrorDecoders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   *ErrorDetail
+	}{
+		{
+			name: "Virtuoso",
+			body: "Virtuoso 37000 Error SP030: SPARQL compiler, line 3: syntax error at 'foo' before '}'\n\n" +
+				"SPARQL query:\nSELECT * WHERE {\n?s ?p ?o\nfoo }",
+			want: &ErrorDetail{
+				Code:    "SP030",
+				Message: "SPARQL compiler, line 3: syntax error at 'foo' before '}'",
+				Line:    3,
+				Syntax:  true,
+			},
+		},
+		{
+			name: "Virtuoso not syntax",
+			body: "Virtuoso 42000 Error SR186: No permission to execute procedure DB.DBA.SPARUL_RUN",
+			want: &ErrorDetail{Code: "SR186", Message: "No permission to execute procedure DB.DBA.SPARUL_RUN"},
+		},
+		{
+			name: "Fuseki",
+			body: "Error 400: Parse error: \nSELECT * WHERE { ?s ?p }\n" +
+				"Encountered \" \"}\" \"} \"\" at line 1, column 24.\nWas expecting one of:\n    <IRIref> ...\n",
+			want: &ErrorDetail{
+				Message: "Parse error: Encountered \" \"}\" \"} \"\" at line 1, column 24.",
+				Line:    1,
+				Column:  24,
+				Syntax:  true,
+			},
+		},
+		{
+			name: "Fuseki not syntax",
+			body: "Error 404: Service Description: /ds/foo\n",
+			want: &ErrorDetail{Message: "Service Description: /ds/foo"},
+		},
+		{
+			name: "GraphDB",
+			body: "MALFORMED QUERY: Lexical error at line 2, column 5.  Encountered: \"~\" (126), after : \"\"",
+			want: &ErrorDetail{
+				Code:    "MALFORMED QUERY",
+				Message: "Lexical error at line 2, column 5.  Encountered: \"~\" (126), after : \"\"",
+				Line:    2,
+				Column:  5,
+				Syntax:  true,
+			},
+		},
+		{
+			name:   "Stardog",
+			header: http.Header{"Sd-Error-Code": []string{"QE0PE2"}},
+			body:   `{"message":"com.complexible.stardog.plan.eval.ExecutionException: Encountered \" \"}\" \"} \"\" at line 1, column 24.","code":"QE0PE2"}`,
+			want: &ErrorDetail{
+				Code:    "QE0PE2",
+				Message: `com.complexible.stardog.plan.eval.ExecutionException: Encountered " "}" "} "" at line 1, column 24.`,
+				Line:    1,
+				Column:  24,
+				Syntax:  true,
+			},
+		},
+		{
+			name: "Blazegraph",
+			body: "SPARQL-QUERY: queryStr=SELECT * { ?s ?p }\n" +
+				"java.util.concurrent.ExecutionException: org.openrdf.query.MalformedQueryException: " +
+				"Encountered \" \"}\" \"} \"\" at line 1, column 17.\n" +
+				"\tat java.util.concurrent.FutureTask.report(FutureTask.java:122)\n",
+			want: &ErrorDetail{
+				Code:    "MalformedQueryException",
+				Message: `Encountered " "}" "} "" at line 1, column 17.`,
+				Line:    1,
+				Column:  17,
+				Syntax:  true,
+			},
+		},
+		{
+			name: "Neptune",
+			body: `{"requestId":"1a2b","code":"MalformedQueryException","detailedMessage":"Malformed query: Encountered \"}\" at line 1, column 24."}`,
+			want: &ErrorDetail{
+				Code:    "MalformedQueryException",
+				Message: `Malformed query: Encountered "}" at line 1, column 24.`,
+				Line:    1,
+				Column:  24,
+				Syntax:  true,
+			},
+		},
+		{
+			name: "HTML",
+			body: "<!DOCTYPE html>\n<html><head><title>Error 503 Service Unavailable</title></head>\n" +
+				"<body><h2>HTTP ERROR 503</h2><p>Problem accessing /sparql. Reason:<pre>    Service Unavailable</pre></p></body></html>",
+			want: &ErrorDetail{Message: "Error 503 Service Unavailable"},
+		},
+		{
+			name:   "HTML with position",
+			header: http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+			body:   "<h1>Bad Request</h1><pre>Lexical error at line 1, column 8.</pre>",
+			want:   &ErrorDetail{Message: "Lexical error at line 1, column 8.", Line: 1, Column: 8, Syntax: true},
+		},
+		{name: "unknown", body: "something went wrong"},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &HTTPError{Header: tt.header, Body: []byte(tt.body)}
+			var got *ErrorDetail
+			for _, decode := range DefaultErrorDecoders() {
+				if got = decode(e); got != nil {
+					break
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decoded = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithErrorDecoders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Virtuoso 37000 Error SP030: SPARQL compiler, line 1: syntax error", http.StatusInternalServerError)
+		},
+	))
+	defer server.Close()
+	ctx := context.Background()
+
+	t.Run("default", func(t *testing.T) {
+		c, err := New(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Query(ctx, "SELECT * { ?s ?p ?o }")
+		if !errors.Is(err, ErrSyntax) {
+			t.Errorf("Client.Query() error = %v, want ErrSyntax", err)
+		}
+		if want := "SPARQL query error. status code: 500 msg: SP030 syntax error at line 1: " +
+			"SPARQL compiler, line 1: syntax error"; err == nil || err.Error() != want {
+			t.Errorf("Client.Query() error = %v, want %v", err, want)
+		}
+	})
+	t.Run("custom", func(t *testing.T) {
+		custom := func(e *HTTPError) *ErrorDetail {
+			return &ErrorDetail{Code: "custom", Message: "decoded"}
+		}
+		c, err := New(server.URL, WithHTTPClient(server.Client()),
+			WithErrorDecoders(append([]ErrorDecoder{custom}, DefaultErrorDecoders()...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Query(ctx, "SELECT * { ?s ?p ?o }")
+		var herr *HTTPError
+		if !errors.As(err, &herr) || herr.Detail == nil || herr.Detail.Code != "custom" {
+			t.Errorf("Client.Query() error = %v", err)
+		}
+		if errors.Is(err, ErrSyntax) {
+			t.Errorf("Client.Query() error = %v, want not ErrSyntax", err)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+)
+
+// Errors classified from the status code of `*HTTPError`. Use `errors.Is` to test them.
+var (
+	// ErrSyntax is a malformed query or update request (400). It's also a decoded
+	// error detail of a syntax error with another status code.
+	ErrSyntax = errors.New("syntax error")
+	// ErrTimeout is a timeout on the server side (408 and 504) or `*TimeoutError`
+	// on the client side.
+	ErrTimeout = errors.New("timeout")
+	// ErrAuth is an authentication or authorization failure (401 and 403).
+	ErrAuth = errors.New("authentication failed")
+	// ErrRateLimited is too many requests (429).
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUnavailable is an unavailable server (502 and 503).
+	ErrUnavailable = errors.New("server unavailable")
+)
+
+// maxErrorBodySize is the max size of the response body kept in `HTTPError`.
+const maxErrorBodySize = 64 << 10
+
+// HTTPError is an unsuccessful response from the endpoint.
+type HTTPError struct {
+	// Op is "query", "update" or "ping".
+	Op         string
+	Endpoint   string
+	StatusCode int
+	Header     http.Header
+	// Body is the response body up to 64 KiB.
+	Body []byte
+	// Query is the query or the update request with the parameters.
+	// It's empty for ping.
+	Query string
+	// Detail is decoded from the body by the error decoders of the client.
+	// It's nil if no decoder recognizes the body.
+	Detail *ErrorDetail
+	// Attempts is the number of the attempts including retries by the retry policy.
+	Attempts int
+}
+
+// newHTTPError reads the bounded body and decodes it. The caller closes the body.
+func (c *Client) newHTTPError(op, endpoint, query string, resp *http.Response) error {
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return err
+	}
+	e := &HTTPError{
+		Op:         op,
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Query:      query,
+	}
+	for _, decode := range c.errorDecoders {
+		if e.Detail = decode(e); e.Detail != nil {
+			break
+		}
+	}
+	return e
+}
+
+// Error returns the status code and the detail or the first line of the body.
+func (e *HTTPError) Error() string {
+	prefix := fmt.Sprintf("SPARQL %s error", e.Op)
+	if e.Attempts > 1 {
+		prefix += fmt.Sprintf(" after %d attempts", e.Attempts)
+	}
+	if e.Detail != nil {
+		return fmt.Sprintf("%s. status code: %d msg: %s", prefix, e.StatusCode, e.Detail)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(e.Body))
+	if scanner.Scan() && scanner.Text() != "" {
+		return fmt.Sprintf("%s. status code: %d msg: %s", prefix, e.StatusCode, scanner.Text())
+	}
+	return fmt.Sprintf("%s. status code: %d", prefix, e.StatusCode)
+}
+
+// Is reports whether the status code is classified as target.
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrSyntax:
+		return e.StatusCode == http.StatusBadRequest || e.Detail != nil && e.Detail.Syntax
+	case ErrTimeout:
+		return e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusGatewayTimeout
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusBadGateway || e.StatusCode == http.StatusServiceUnavailable
+	default:
+		return false
+	}
+}
+
+// TimeoutError is a timeout on the client side such as the context deadline or
+// `http.Client.Timeout`. It's classified as `ErrTimeout` and wraps the cause such as
+// `context.DeadlineExceeded`.
+type TimeoutError struct {
+	Err error
+}
+
+// Error returns the message of the cause.
+func (e *TimeoutError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is `ErrTimeout`.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// timeoutError wraps err with `*TimeoutError` if it's a timeout on the client side.
+func timeoutError(err error) error {
+	var timeout *TimeoutError
+	if err == nil || errors.As(err, &timeout) {
+		return err
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{Err: err}
+	}
+	return err
+}
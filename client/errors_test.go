@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nolint: scopelint
+func TestHTTPError_Is(t *testing.T) {
+	targets := []error{ErrSyntax, ErrTimeout, ErrAuth, ErrRateLimited, ErrUnavailable}
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{statusCode: http.StatusBadRequest, want: ErrSyntax},
+		{statusCode: http.StatusRequestTimeout, want: ErrTimeout},
+		{statusCode: http.StatusGatewayTimeout, want: ErrTimeout},
+		{statusCode: http.StatusUnauthorized, want: ErrAuth},
+		{statusCode: http.StatusForbidden, want: ErrAuth},
+		{statusCode: http.StatusTooManyRequests, want: ErrRateLimited},
+		{statusCode: http.StatusBadGateway, want: ErrUnavailable},
+		{statusCode: http.StatusServiceUnavailable, want: ErrUnavailable},
+		{statusCode: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			err := &HTTPError{StatusCode: tt.statusCode}
+			for _, target := range targets {
+				if got := errors.Is(err, target); got != (target == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, target, got)
+				}
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestHTTPError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *HTTPError
+		want string
+	}{
+		{
+			name: "body",
+			err:  &HTTPError{Op: "query", StatusCode: 400, Body: []byte("bad query\nat line 1\n")},
+			want: "SPARQL query error. status code: 400 msg: bad query",
+		},
+		{
+			name: "no body",
+			err:  &HTTPError{Op: "ping", StatusCode: 503},
+			want: "SPARQL ping error. status code: 503",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("HTTPError.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPError_response(t *testing.T) {
+	body := "Too many requests\n" + strings.Repeat("x", maxErrorBodySize)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			if r.Method != http.MethodHead {
+				_, _ = w.Write([]byte(body))
+			}
+		},
+	))
+	defer server.Close()
+
+	c, err := New(server.URL, WithHTTPClient(server.Client()), WithUpdateEndpoint(server.URL+"/update"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	check := func(t *testing.T, err error, op, endpoint, query string) {
+		t.Helper()
+		if !errors.Is(err, ErrRateLimited) {
+			t.Errorf("error = %v, want ErrRateLimited", err)
+		}
+		var herr *HTTPError
+		if !errors.As(err, &herr) {
+			t.Fatalf("error = %v, want *HTTPError", err)
+		}
+		if herr.Op != op || herr.Endpoint != endpoint || herr.Query != query ||
+			herr.StatusCode != http.StatusTooManyRequests || herr.Header.Get("Retry-After") != "10" {
+			t.Errorf("HTTPError = %+v", herr)
+		}
+		if op != "ping" && string(herr.Body) != body[:maxErrorBodySize] {
+			t.Errorf("HTTPError.Body has %d bytes, want %d", len(herr.Body), maxErrorBodySize)
+		}
+	}
+
+	t.Run("query", func(t *testing.T) {
+		_, err := c.Query(ctx, "ASK { ?s ?p $1 }", Param{Ordinal: 1, Value: 1})
+		check(t, err, "query", server.URL, "ASK { ?s ?p 1 }")
+	})
+	t.Run("update", func(t *testing.T) {
+		err := c.Update(ctx, "CLEAR GRAPH $1", Param{Ordinal: 1, Value: URI("http://example.com/g")})
+		check(t, err, "update", server.URL+"/update", "CLEAR GRAPH <http://example.com/g>")
+	})
+	t.Run("ping", func(t *testing.T) {
+		check(t, c.Ping(ctx), "ping", server.URL, "")
+	})
+}
+
+func TestTimeoutError(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		},
+	))
+	defer server.Close()
+	defer close(done)
+
+	check := func(t *testing.T, err error) {
+		t.Helper()
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("error = %v, want ErrTimeout", err)
+		}
+		var timeout *TimeoutError
+		if !errors.As(err, &timeout) {
+			t.Errorf("error = %v, want *TimeoutError", err)
+		}
+	}
+
+	t.Run("http client timeout", func(t *testing.T) {
+		httpClient := server.Client()
+		httpClient.Timeout = 50 * time.Millisecond
+		c, err := New(server.URL, WithHTTPClient(httpClient))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Query(context.Background(), "ASK { ?s ?p ?o }")
+		check(t, err)
+	})
+	t.Run("context deadline", func(t *testing.T) {
+		c, err := New(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = c.Query(ctx, "ASK { ?s ?p ?o }")
+		check(t, err)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		err := timeoutError(context.Canceled)
+		if errors.Is(err, ErrTimeout) {
+			t.Errorf("errors.Is(%v, ErrTimeout) = true", err)
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// Triple is an RDF triple. Each term is one of URI, Literal or BNode.
+type Triple struct {
+	Subject   Value
+	Predicate Value
+	Object    Value
+}
+
+// Quad is an RDF quad. Graph is nil for the default graph,
+// otherwise it's the graph name, URI or BNode.
+type Quad struct {
+	Triple
+	Graph Value
+}
+
+// GraphResult is an RDF graph returned by CONSTRUCT or DESCRIBE queries.
+type GraphResult interface {
+	// Next returns the next triple. It returns `io.EOF` after the last triple.
+	Next() (Triple, error)
+
+	io.Closer
+}
+
+// GraphParser is the parser for specific format RDF graphs.
+type GraphParser interface {
+	// Format returns a media type. It's used as an `Accept` request header value.
+	Format() string
+	// Parse parses RDF graph stream.
+	Parse(reader io.ReadCloser) (GraphResult, error)
+}
+
+// QuadResult is an RDF dataset with named graphs.
+type QuadResult interface {
+	// Next returns the next quad. It returns `io.EOF` after the last quad.
+	Next() (Quad, error)
+
+	io.Closer
+}
+
+// QuadParser is the parser for specific format RDF datasets.
+type QuadParser interface {
+	// Format returns a media type. It's used as an `Accept` request header value.
+	Format() string
+	// Parse parses RDF dataset stream.
+	Parse(reader io.ReadCloser) (QuadResult, error)
+}
+
+// Construct queries to the endpoint with CONSTRUCT or DESCRIBE query form.
+func (c *Client) Construct(
+	ctx context.Context,
+	query string,
+	params ...Param,
+) (GraphResult, error) {
+	return c.Prepare(query).Construct(ctx, params...)
+}
+
+// ConstructQuads queries to the endpoint with CONSTRUCT or DESCRIBE query form and
+// receives the result as an RDF dataset with the graph names.
+func (c *Client) ConstructQuads(
+	ctx context.Context,
+	query string,
+	params ...Param,
+) (QuadResult, error) {
+	return c.Prepare(query).ConstructQuads(ctx, params...)
+}
+
+// Construct queries to the endpoint with CONSTRUCT or DESCRIBE query form.
+// The graph format is negotiated with the `Accept` header among the graph parsers.
+// The returned `GraphResult` owns the response body. Close it to release the connection.
+func (s *Statement) Construct(
+	ctx context.Context,
+	params ...Param,
+) (GraphResult, error) {
+	body, contentType, err := s.graphResponse(ctx, "Construct", s.c.accept(), params)
+	if err != nil {
+		return nil, err
+	}
+	graphParser, err := s.c.graphParser(contentType)
+	if err != nil {
+		_ = body.Close()
+		return nil, err
+	}
+	result, err := graphParser.Parse(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, contextError(ctx, err)
+	}
+	return &contextGraphResult{GraphResult: result, ctx: ctx}, nil
+}
+
+// ConstructQuads queries to the endpoint with CONSTRUCT or DESCRIBE query form.
+// The dataset format is negotiated with the `Accept` header among the quad parsers.
+// The returned `QuadResult` owns the response body. Close it to release the connection.
+func (s *Statement) ConstructQuads(
+	ctx context.Context,
+	params ...Param,
+) (QuadResult, error) {
+	formats := make([]string, 0, len(s.c.quadParsers))
+	for _, p := range s.c.quadParsers {
+		formats = append(formats, p.Format())
+	}
+	body, contentType, err := s.graphResponse(ctx, "ConstructQuads", accept(formats), params)
+	if err != nil {
+		return nil, err
+	}
+	i, err := negotiate(formats, contentType)
+	if err != nil {
+		_ = body.Close()
+		return nil, err
+	}
+	result, err := s.c.quadParsers[i].Parse(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, contextError(ctx, err)
+	}
+	return &contextQuadResult{QuadResult: result, ctx: ctx}, nil
+}
+
+// graphResponse sends the CONSTRUCT or DESCRIBE query with the `Accept` header value.
+// It returns the response body and the content type.
+func (s *Statement) graphResponse(
+	ctx context.Context,
+	method string,
+	acceptValue string,
+	params []Param,
+) (io.ReadCloser, string, error) {
+	if err := s.validated(method, parser.FormConstruct, parser.FormDescribe); err != nil {
+		return nil, "", err
+	}
+	request, err := s.newRequest(ctx, "", params...)
+	if err != nil {
+		return nil, "", err
+	}
+	request.Header.Set("Accept", acceptValue)
+
+	resp, err := s.do(request, params)
+	if err != nil {
+		return nil, "", err
+	}
+	return &responseBody{ReadCloser: resp.Body, ctx: ctx}, resp.Header.Get("Content-Type"), nil
+}
+
+// accept returns the `Accept` header value with the graph parsers in order of preference.
+func (c *Client) accept() string {
+	formats := make([]string, 0, len(c.graphParsers))
+	for _, p := range c.graphParsers {
+		formats = append(formats, p.Format())
+	}
+	return accept(formats)
+}
+
+// accept returns the `Accept` header value with the formats in order of preference.
+func accept(formats []string) string {
+	ss := make([]string, 0, len(formats))
+	for i, format := range formats {
+		if i == 0 {
+			ss = append(ss, format)
+			continue
+		}
+		q := 1 - float64(i)/10
+		if q < 0.1 {
+			q = 0.1
+		}
+		ss = append(ss, format+";q="+strconv.FormatFloat(q, 'f', 1, 64))
+	}
+	return strings.Join(ss, ", ")
+}
+
+// graphParser chooses the graph parser for the response content type.
+// The most preferred one is used if the content type is missing.
+func (c *Client) graphParser(contentType string) (GraphParser, error) {
+	formats := make([]string, 0, len(c.graphParsers))
+	for _, p := range c.graphParsers {
+		formats = append(formats, p.Format())
+	}
+	i, err := negotiate(formats, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return c.graphParsers[i], nil
+}
+
+// negotiate returns the index of the format of the response content type.
+// The first one is used if the content type is missing.
+func negotiate(formats []string, contentType string) (int, error) {
+	if len(formats) == 0 {
+		return 0, fmt.Errorf("no graph parser for %q", contentType)
+	}
+	if contentType == "" {
+		return 0, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported graph format %q", contentType)
+	}
+	for i, format := range formats {
+		if format == mediaType {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported graph format %q", mediaType)
+}
+
+// contextGraphResult stops the iteration of `GraphResult` when the context is done.
+type contextGraphResult struct {
+	GraphResult
+	ctx context.Context
+}
+
+// Next returns the context error instead of the next triple once the context is done.
+func (r *contextGraphResult) Next() (Triple, error) {
+	if err := r.ctx.Err(); err != nil {
+		return Triple{}, timeoutError(err)
+	}
+	triple, err := r.GraphResult.Next()
+	if err != nil {
+		return Triple{}, contextError(r.ctx, err)
+	}
+	return triple, nil
+}
+
+// contextQuadResult stops the iteration of `QuadResult` when the context is done.
+type contextQuadResult struct {
+	QuadResult
+	ctx context.Context
+}
+
+// Next returns the context error instead of the next quad once the context is done.
+func (r *contextQuadResult) Next() (Quad, error) {
+	if err := r.ctx.Err(); err != nil {
+		return Quad{}, timeoutError(err)
+	}
+	quad, err := r.QuadResult.Next()
+	if err != nil {
+		return Quad{}, contextError(r.ctx, err)
+	}
+	return quad, nil
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_Construct(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		c, err := New("foo")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if _, err := c.Construct(context.Background(), ""); err == nil {
+			t.Errorf("Client.Construct() error = %v", err)
+		}
+	})
+	t.Run("not ok", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "", http.StatusBadRequest)
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Construct(context.Background(), ""); err == nil {
+			t.Errorf("Client.Construct() error = %v", err)
+		}
+	})
+	t.Run("no parser", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, "")
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL, WithGraphParsers())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Construct(context.Background(), ""); err == nil {
+			t.Errorf("Client.Construct() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		var accept, format string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept")
+				format = r.URL.Query().Get("format")
+				w.Header().Set("Content-Type", "application/n-triples; charset=utf-8")
+				_, _ = fmt.Fprint(w, `<http://example.com/s> <http://example.com/p> "o" .`+"\n")
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.Construct(context.Background(), "CONSTRUCT WHERE { ?s ?p ?o }")
+		if err != nil {
+			t.Errorf("Client.Construct() error = %v", err)
+			return
+		}
+		defer result.Close()
+		if want := "text/turtle, application/n-triples;q=0.9"; accept != want {
+			t.Errorf("Accept = %v, want %v", accept, want)
+		}
+		if format != "" {
+			t.Errorf("format = %v, want empty", format)
+		}
+
+		got, err := result.Next()
+		if err != nil {
+			t.Errorf("GraphResult.Next() error = %v", err)
+			return
+		}
+		want := Triple{URI("http://example.com/s"), URI("http://example.com/p"), Literal{Value: "o"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GraphResult.Next() = %v, want %v", got, want)
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("GraphResult.Next() error = %v", err)
+		}
+	})
+}
+
+func TestClient_ConstructQuads(t *testing.T) {
+	t.Run("no parser", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, "")
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL, WithQuadParsers())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.ConstructQuads(context.Background(), ""); err == nil {
+			t.Errorf("Client.ConstructQuads() error = %v", err)
+		}
+	})
+	t.Run("unsupported format", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/turtle")
+				_, _ = fmt.Fprint(w, "")
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.ConstructQuads(context.Background(), ""); err == nil {
+			t.Errorf("Client.ConstructQuads() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		var accept string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept")
+				w.Header().Set("Content-Type", "application/n-quads")
+				_, _ = fmt.Fprint(w, `<http://example.com/s> <http://example.com/p> "o" <http://example.com/g> .`+"\n")
+			},
+		))
+		defer server.Close()
+
+		c, err := New(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.ConstructQuads(context.Background(), "DESCRIBE <http://example.com/s>")
+		if err != nil {
+			t.Fatalf("Client.ConstructQuads() error = %v", err)
+		}
+		defer result.Close()
+		if want := "application/trig, application/n-quads;q=0.9"; accept != want {
+			t.Errorf("Accept = %v, want %v", accept, want)
+		}
+
+		got, err := result.Next()
+		if err != nil {
+			t.Fatalf("QuadResult.Next() error = %v", err)
+		}
+		want := Quad{
+			Triple: Triple{URI("http://example.com/s"), URI("http://example.com/p"), Literal{Value: "o"}},
+			Graph:  URI("http://example.com/g"),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("QuadResult.Next() = %v, want %v", got, want)
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("QuadResult.Next() error = %v", err)
+		}
+	})
+}
+
+func TestClient_graphParser(t *testing.T) {
+	turtle, nTriples := NewTurtleParser(), NewNTriplesParser()
+	c := &Client{graphParsers: []GraphParser{turtle, nTriples}}
+	tests := map[string]GraphParser{
+		"text/turtle":                          turtle,
+		"application/n-triples":                nTriples,
+		"application/n-triples; charset=utf-8": nTriples,
+		"":                                     turtle,
+	}
+	for contentType, want := range tests {
+		got, err := c.graphParser(contentType)
+		if err != nil {
+			t.Errorf("Client.graphParser(%q) error = %v", contentType, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Client.graphParser(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+
+	for _, contentType := range []string{"application/rdf+xml", "application/ld+json; charset=utf-8", "text/"} {
+		if _, err := c.graphParser(contentType); err == nil ||
+			!strings.HasPrefix(err.Error(), "unsupported graph format") {
+			t.Errorf("Client.graphParser(%q) error = %v", contentType, err)
+		}
+	}
+}
+
+func TestClient_accept(t *testing.T) {
+	parsers := make([]GraphParser, 0, 12)
+	for i := 0; i < 12; i++ {
+		parsers = append(parsers, NewNTriplesParser())
+	}
+	c := &Client{graphParsers: parsers[:3]}
+	if got, want := c.accept(), "application/n-triples, application/n-triples;q=0.9, application/n-triples;q=0.8"; got != want {
+		t.Errorf("Client.accept() = %v, want %v", got, want)
+	}
+	c = &Client{graphParsers: parsers}
+	if got, want := c.accept(), "application/n-triples;q=0.1"; got[len(got)-len(want):] != want {
+		t.Errorf("Client.accept() = %v, want suffix %v", got, want)
+	}
+}
+
+func Test_contextGraphResult_Next(t *testing.T) {
+	result, err := DecodeNTriples(ioutil.NopCloser(strings.NewReader(`<http://example.com/s> <http://example.com/p> <http://example.com/o> .`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &contextGraphResult{GraphResult: result, ctx: ctx}
+	if _, err := r.Next(); err != nil {
+		t.Errorf("contextGraphResult.Next() error = %v", err)
+	}
+	cancel()
+	if _, err := r.Next(); err != context.Canceled {
+		t.Errorf("contextGraphResult.Next() error = %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package client
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ScanGrouped reads all bindings of the result and folds them into the slice pointed by dst.
+// The slice elements are structs or pointers to structs.
+//
+// The rows are grouped by the variables of the fields tagged with the `key` option,
+// for example `sparql:"s,key"`. A row whose key variables are not all bound is skipped.
+// The groups are stored in the order of their first rows. Within a group,
+//
+//   - scalar fields take the first bound values as `DecodeBindings` does.
+//   - slice fields, except []byte, take the distinct bound values in order.
+//   - struct fields, pointers to structs and slices of them are nested groups.
+//     They are grouped by their own key fields in the same way. A nested group
+//     without key fields folds all rows of the outer group into one element.
+//     Nested groups without bound variables are nil or empty.
+//
+// Structs implementing `sql.Scanner`, time.Time and the RDF term types are scalars.
+// The result is read until `io.EOF` but not closed.
+func ScanGrouped(result QueryResult, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("destination must be a non-nil pointer to a slice, got %T", dst)
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	ptr := elemType.Kind() == reflect.Ptr
+	if ptr {
+		elemType = elemType.Elem()
+	}
+	if !isNestedStruct(elemType) {
+		return fmt.Errorf("slice element must be a struct, got %s", slice.Type().Elem())
+	}
+	plan, err := newStructPlan(elemType, map[reflect.Type]bool{})
+	if err != nil {
+		return err
+	}
+	if len(plan.keys) == 0 {
+		return fmt.Errorf("%s has no key field", elemType)
+	}
+
+	groups := newGroupSet(plan)
+	for {
+		bindings, err := result.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := groups.add(bindings); err != nil {
+			return err
+		}
+	}
+	slice.Set(groups.slice(slice.Type(), ptr))
+	return nil
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// isNestedStruct reports whether the struct type is a nested group, not a scalar.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !termTypes[t] && t != timeType &&
+		!reflect.PtrTo(t).Implements(scannerType)
+}
+
+type fieldKind int
+
+const (
+	scalarField fieldKind = iota
+	sliceField
+	structField
+	structSliceField
+)
+
+// fieldPlan describes how a field takes bindings.
+type fieldPlan struct {
+	index []int
+	// fieldName is the Go field name for error messages.
+	fieldName string
+	tag       structTag
+	kind      fieldKind
+	// ptr reports whether the nested structs are pointers.
+	ptr bool
+	// plan is the plan of the nested structs.
+	plan *structPlan
+}
+
+// structPlan describes how a struct type takes bindings.
+type structPlan struct {
+	typ    reflect.Type
+	fields []*fieldPlan
+	keys   []*fieldPlan
+}
+
+func newStructPlan(t reflect.Type, visiting map[reflect.Type]bool) (*structPlan, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive struct %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	plan := &structPlan{typ: t}
+	if err := plan.addFields(t, nil, visiting); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// addFields adds the fields of the struct type. Embedded structs are flattened.
+func (p *structPlan) addFields(t reflect.Type, index []int, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fieldIndex := append(index[:len(index):len(index)], i)
+		tag, tagged := f.Tag.Lookup("sparql")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			if err := p.addFields(f.Type, fieldIndex, visiting); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		fp := &fieldPlan{index: fieldIndex, fieldName: f.Name, tag: parseStructTag(tag, f.Name)}
+		nested := f.Type
+		switch {
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() != reflect.Uint8:
+			fp.kind = sliceField
+			nested = f.Type.Elem()
+			if nested.Kind() == reflect.Ptr && isNestedStruct(nested.Elem()) {
+				fp.kind, fp.ptr, nested = structSliceField, true, nested.Elem()
+			} else if isNestedStruct(nested) {
+				fp.kind = structSliceField
+			}
+		case f.Type.Kind() == reflect.Ptr && isNestedStruct(f.Type.Elem()):
+			fp.kind, fp.ptr, nested = structField, true, f.Type.Elem()
+		case isNestedStruct(f.Type):
+			fp.kind = structField
+		}
+		if fp.kind == structField || fp.kind == structSliceField {
+			plan, err := newStructPlan(nested, visiting)
+			if err != nil {
+				return err
+			}
+			fp.plan = plan
+		}
+
+		p.fields = append(p.fields, fp)
+		if fp.tag.key && fp.kind == scalarField {
+			p.keys = append(p.keys, fp)
+		}
+	}
+	return nil
+}
+
+// bound reports whether the bindings make a group of the plan.
+func (p *structPlan) bound(bindings map[string]Value) bool {
+	if len(p.keys) > 0 {
+		for _, k := range p.keys {
+			if bindings[k.tag.name] == nil {
+				return false
+			}
+		}
+		return true
+	}
+	for _, f := range p.fields {
+		if f.plan != nil {
+			if f.plan.bound(bindings) {
+				return true
+			}
+			continue
+		}
+		if selectLang(bindings[f.tag.name], f.tag.lang) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the group key of the bindings.
+func (p *structPlan) key(bindings map[string]Value) string {
+	keys := make([]string, 0, len(p.keys))
+	for _, k := range p.keys {
+		keys = append(keys, fmt.Sprintf("%#v", bindings[k.tag.name]))
+	}
+	return strings.Join(keys, "\x00")
+}
+
+// groupSet is the groups of a plan in the order of their first rows.
+type groupSet struct {
+	plan   *structPlan
+	groups map[string]*group
+	order  []*group
+}
+
+func newGroupSet(plan *structPlan) *groupSet {
+	return &groupSet{plan: plan, groups: make(map[string]*group)}
+}
+
+func (s *groupSet) add(bindings map[string]Value) error {
+	if !s.plan.bound(bindings) {
+		return nil
+	}
+	key := s.plan.key(bindings)
+	g, ok := s.groups[key]
+	if !ok {
+		g = &group{
+			value:    reflect.New(s.plan.typ).Elem(),
+			filled:   make(map[*fieldPlan]bool),
+			seen:     make(map[*fieldPlan]map[Value]bool),
+			children: make(map[*fieldPlan]*groupSet),
+		}
+		s.groups[key] = g
+		s.order = append(s.order, g)
+	}
+	return g.add(s.plan, bindings)
+}
+
+// slice returns the built structs as a slice of the type.
+func (s *groupSet) slice(t reflect.Type, ptr bool) reflect.Value {
+	slice := reflect.MakeSlice(t, 0, len(s.order))
+	for _, g := range s.order {
+		slice = reflect.Append(slice, g.build(ptr))
+	}
+	return slice
+}
+
+// group is a struct being folded from the rows.
+type group struct {
+	value reflect.Value
+	// filled is the scalar fields already set.
+	filled map[*fieldPlan]bool
+	// seen is the values already appended to the slice fields.
+	seen     map[*fieldPlan]map[Value]bool
+	children map[*fieldPlan]*groupSet
+}
+
+func (g *group) add(plan *structPlan, bindings map[string]Value) error {
+	for _, f := range plan.fields {
+		switch f.kind {
+		case scalarField:
+			term := selectLang(bindings[f.tag.name], f.tag.lang)
+			if term == nil || g.filled[f] {
+				continue
+			}
+			if err := decodeField(g.value.FieldByIndex(f.index), term, ""); err != nil {
+				return fmt.Errorf("field %s: %v", f.fieldName, err)
+			}
+			g.filled[f] = true
+		case sliceField:
+			term := selectLang(bindings[f.tag.name], f.tag.lang)
+			if term == nil || g.seen[f][term] {
+				continue
+			}
+			field := g.value.FieldByIndex(f.index)
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := decodeField(elem, term, ""); err != nil {
+				return fmt.Errorf("field %s: %v", f.fieldName, err)
+			}
+			field.Set(reflect.Append(field, elem))
+			if g.seen[f] == nil {
+				g.seen[f] = make(map[Value]bool)
+			}
+			g.seen[f][term] = true
+		default:
+			children, ok := g.children[f]
+			if !ok {
+				children = newGroupSet(f.plan)
+				g.children[f] = children
+			}
+			if err := children.add(bindings); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// build sets the nested groups into the struct and returns it or the pointer to it.
+func (g *group) build(ptr bool) reflect.Value {
+	for f, children := range g.children {
+		field := g.value.FieldByIndex(f.index)
+		switch f.kind {
+		case structField:
+			if len(children.order) > 0 {
+				field.Set(children.order[0].build(f.ptr))
+			}
+		case structSliceField:
+			field.Set(children.slice(field.Type(), f.ptr))
+		}
+	}
+	if ptr {
+		return g.value.Addr()
+	}
+	return g.value
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type groupAddress struct {
+	City string `sparql:"city"`
+}
+
+type groupFriend struct {
+	ID   URI    `sparql:"f,key"`
+	Name string `sparql:"fname"`
+}
+
+type groupPerson struct {
+	ID      URI      `sparql:"s,key"`
+	Name    string   `sparql:"name"`
+	Nicks   []string `sparql:"nick,lang=en"`
+	Address *groupAddress
+	Friends []groupFriend
+}
+
+func TestScanGrouped(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := DecodeTSVQueryResult(ioutil.NopCloser(strings.NewReader(strings.Join([]string{
+			"?s\t?name\t?nick\t?city\t?f\t?fname",
+			"<http://example.com/a>\t\"Alice\"\t\"Al\"@en\t\"Tokyo\"\t<http://example.com/b>\t\"Bob\"",
+			"<http://example.com/a>\t\"Alice\"\t\"Ali\"@en\t\"Tokyo\"\t<http://example.com/b>\t\"Bob\"",
+			"<http://example.com/a>\t\"Alice\"\t\"Al\"@en\t\"Tokyo\"\t<http://example.com/c>\t\"Carol\"",
+			"<http://example.com/a>\t\"Alice\"\t\"アリス\"@ja\t\"Tokyo\"\t<http://example.com/c>\t\"Carol\"",
+			"<http://example.com/b>\t\"Bob\"\t\t\t\t",
+			"\t\"nobody\"\t\t\t\t",
+		}, "\n"))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []groupPerson
+		if err := ScanGrouped(result, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := []groupPerson{
+			{
+				ID:      URI("http://example.com/a"),
+				Name:    "Alice",
+				Nicks:   []string{"Al", "Ali"},
+				Address: &groupAddress{City: "Tokyo"},
+				Friends: []groupFriend{
+					{ID: URI("http://example.com/b"), Name: "Bob"},
+					{ID: URI("http://example.com/c"), Name: "Carol"},
+				},
+			},
+			{
+				ID:      URI("http://example.com/b"),
+				Name:    "Bob",
+				Friends: []groupFriend{},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ScanGrouped() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("pointer elements", func(t *testing.T) {
+		result, err := DecodeTSVQueryResult(ioutil.NopCloser(strings.NewReader(
+			"?f\t?fname\n<http://example.com/b>\t\"Bob\"\n<http://example.com/b>\t\"Robert\"\n",
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []*groupFriend
+		if err := ScanGrouped(result, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := []*groupFriend{{ID: URI("http://example.com/b"), Name: "Bob"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ScanGrouped() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("no key", func(t *testing.T) {
+		var got []groupAddress
+		if err := ScanGrouped(&mockQueryResult{}, &got); err == nil {
+			t.Errorf("ScanGrouped() error = %v", err)
+		}
+	})
+	t.Run("not a slice", func(t *testing.T) {
+		var got groupPerson
+		if err := ScanGrouped(&mockQueryResult{}, &got); err == nil {
+			t.Errorf("ScanGrouped() error = %v", err)
+		}
+	})
+	t.Run("recursive", func(t *testing.T) {
+		type node struct {
+			ID       URI `sparql:"s,key"`
+			Children []node
+		}
+		var got []node
+		if err := ScanGrouped(&mockQueryResult{}, &got); err == nil {
+			t.Errorf("ScanGrouped() error = %v", err)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		resultErr := errors.New("error")
+		var got []groupPerson
+		if err := ScanGrouped(&mockQueryResult{err: resultErr}, &got); err != resultErr {
+			t.Errorf("ScanGrouped() error = %v", err)
+		}
+	})
+}
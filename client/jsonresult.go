@@ -0,0 +1,337 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// JSONResultParser is the parser for JSON-formatted query results.
+type JSONResultParser struct {
+}
+
+// NewJSONResultParser returns `JSONResultParser` as the implementation of `ResultParser`.
+func NewJSONResultParser() ResultParser {
+	return &JSONResultParser{}
+}
+
+// Parse parses the JSON query result stream.
+func (*JSONResultParser) Parse(r io.ReadCloser) (QueryResult, error) {
+	return DecodeJSONQueryResult(r)
+}
+
+// Format returns a format name string. It's used as a `format` request header value.
+func (*JSONResultParser) Format() string {
+	return "application/sparql-results+json"
+}
+
+// jsonState is the position of the decoder in the result document.
+type jsonState int
+
+const (
+	// jsonTop is inside the top-level object.
+	jsonTop jsonState = iota
+	// jsonResults is inside the `results` object.
+	jsonResults
+	// jsonBindings is inside the `results.bindings` array.
+	jsonBindings
+	// jsonDone is after the end of the top-level object.
+	jsonDone
+)
+
+// JSONQueryResult is the implementation to decode SPARQL 1.1 Query Results JSON Format.
+// https://www.w3.org/TR/sparql11-results-json/
+//
+// `results.bindings` is decoded one row at a time. It's buffered only if `results`
+// precedes `head`.
+type JSONQueryResult struct {
+	r          io.ReadCloser
+	variables  []string
+	decoder    *json.Decoder
+	state      jsonState
+	boolean    bool
+	hasBoolean bool
+	buffered   []map[string]jsonTerm
+}
+
+type jsonHead struct {
+	Vars []string `json:"vars"`
+}
+
+type jsonResultsMember struct {
+	Bindings []map[string]jsonTerm `json:"bindings"`
+}
+
+type jsonTerm struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Lang     string `json:"xml:lang"`
+	DataType string `json:"datatype"`
+}
+
+// DecodeJSONQueryResult decodes responded JSON Query Result.
+func DecodeJSONQueryResult(r io.ReadCloser) (QueryResult, error) {
+	x := &JSONQueryResult{
+		r:       r,
+		decoder: json.NewDecoder(r),
+	}
+	if err := expectDelim(x.decoder, '{'); err != nil {
+		return nil, err
+	}
+	if err := x.decodeHead(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// decodeHead reads top-level members until `head` is found.
+// `boolean` and `results` members preceding `head` are kept.
+func (x *JSONQueryResult) decodeHead() error {
+	for {
+		key, err := nextKey(x.decoder)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "head":
+			var h jsonHead
+			if err := x.decoder.Decode(&h); err != nil {
+				return err
+			}
+			x.variables = make([]string, 0, len(h.Vars))
+			x.variables = append(x.variables, h.Vars...)
+			return nil
+		case "boolean":
+			if err := x.decodeBoolean(); err != nil {
+				return err
+			}
+		case "results":
+			var results jsonResultsMember
+			if err := x.decoder.Decode(&results); err != nil {
+				return err
+			}
+			x.buffered = append(x.buffered, results.Bindings...)
+		default:
+			if err := skipValue(x.decoder); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Variables returns query variables.
+func (x *JSONQueryResult) Variables() []string {
+	return x.variables
+}
+
+// Next returns the next bindings. It returns `io.EOF` after the last bindings.
+// nolint: gocyclo
+func (x *JSONQueryResult) Next() (map[string]Value, error) {
+	if len(x.buffered) > 0 {
+		terms := x.buffered[0]
+		x.buffered = x.buffered[1:]
+		return jsonBindingsOf(terms, len(x.variables))
+	}
+	for {
+		switch x.state {
+		case jsonTop:
+			key, err := nextKey(x.decoder)
+			if err == io.EOF {
+				x.state = jsonDone
+				return nil, io.EOF
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := x.enterTop(key); err != nil {
+				return nil, err
+			}
+		case jsonResults:
+			key, err := nextKey(x.decoder)
+			if err == io.EOF {
+				x.state = jsonTop
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := x.enterResults(key); err != nil {
+				return nil, err
+			}
+		case jsonBindings:
+			if x.decoder.More() {
+				return decodeJSONBindings(x.decoder, len(x.variables))
+			}
+			if err := expectDelim(x.decoder, ']'); err != nil {
+				return nil, err
+			}
+			x.state = jsonResults
+		default:
+			return nil, io.EOF
+		}
+	}
+}
+
+func (x *JSONQueryResult) enterTop(key string) error {
+	switch key {
+	case "results":
+		if err := expectDelim(x.decoder, '{'); err != nil {
+			return err
+		}
+		x.state = jsonResults
+		return nil
+	case "boolean":
+		return x.decodeBoolean()
+	default:
+		return skipValue(x.decoder)
+	}
+}
+
+func (x *JSONQueryResult) enterResults(key string) error {
+	if key != "bindings" {
+		return skipValue(x.decoder)
+	}
+	if err := expectDelim(x.decoder, '['); err != nil {
+		return err
+	}
+	x.state = jsonBindings
+	return nil
+}
+
+func decodeJSONBindings(decoder *json.Decoder, size int) (map[string]Value, error) {
+	var terms map[string]jsonTerm
+	if err := decoder.Decode(&terms); err != nil {
+		return nil, err
+	}
+	return jsonBindingsOf(terms, size)
+}
+
+func jsonBindingsOf(terms map[string]jsonTerm, size int) (map[string]Value, error) {
+	bindings := make(map[string]Value, size)
+	for name, term := range terms {
+		value, err := term.value()
+		if err != nil {
+			return nil, err
+		}
+		bindings[name] = value
+	}
+	return bindings, nil
+}
+
+func (t jsonTerm) value() (Value, error) {
+	switch t.Type {
+	case "uri":
+		return URI(t.Value), nil
+	case "literal", "typed-literal":
+		literal := Literal{
+			Value:       t.Value,
+			LanguageTag: t.Lang,
+		}
+		if t.DataType != "" {
+			literal.DataType = URI(t.DataType)
+		}
+		return literal, nil
+	case "bnode":
+		return BNode(t.Value), nil
+	default:
+		return nil, fmt.Errorf("unknown binding %v", t.Type)
+	}
+}
+
+// Form returns `parser.FormAsk` if the document has `boolean`, otherwise `parser.FormSelect`.
+// It reads top-level members until `boolean` or `results` is found.
+func (x *JSONQueryResult) Form() (parser.Form, error) {
+	for !x.hasBoolean && x.state == jsonTop {
+		key, err := nextKey(x.decoder)
+		if err == io.EOF {
+			x.state = jsonDone
+			break
+		}
+		if err != nil {
+			return parser.FormUnknown, err
+		}
+		if err := x.enterTop(key); err != nil {
+			return parser.FormUnknown, err
+		}
+	}
+	if x.hasBoolean {
+		return parser.FormAsk, nil
+	}
+	return parser.FormSelect, nil
+}
+
+// Boolean returns the result of the ASK query.
+func (x *JSONQueryResult) Boolean() (bool, error) {
+	for !x.hasBoolean {
+		if x.state != jsonTop {
+			return false, io.EOF
+		}
+		key, err := nextKey(x.decoder)
+		if err == io.EOF {
+			x.state = jsonDone
+			return false, io.EOF
+		}
+		if err != nil {
+			return false, err
+		}
+		if key != "boolean" {
+			if err := skipValue(x.decoder); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if err := x.decodeBoolean(); err != nil {
+			return false, err
+		}
+	}
+	return x.boolean, nil
+}
+
+func (x *JSONQueryResult) decodeBoolean() error {
+	if err := x.decoder.Decode(&x.boolean); err != nil {
+		return err
+	}
+	x.hasBoolean = true
+	return nil
+}
+
+// Close closes the underlying reader.
+func (x *JSONQueryResult) Close() error {
+	return x.r.Close()
+}
+
+// nextKey reads the next member name of the current object.
+// It returns `io.EOF` at the end of the object.
+func nextKey(decoder *json.Decoder) (string, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", err
+	}
+	switch token := token.(type) {
+	case string:
+		return token, nil
+	case json.Delim:
+		if token == '}' {
+			return "", io.EOF
+		}
+	}
+	return "", fmt.Errorf("unexpected JSON token %v", token)
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected JSON token %v, want %v", token, delim)
+	}
+	return nil
+}
+
+func skipValue(decoder *json.Decoder) error {
+	var raw json.RawMessage
+	return decoder.Decode(&raw)
+}
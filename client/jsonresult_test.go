@@ -0,0 +1,283 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/garsue/sparql/parser"
+)
+
+func TestJSONResultParser_Format(t *testing.T) {
+	if got, want := NewJSONResultParser().Format(), "application/sparql-results+json"; got != want {
+		t.Errorf("JSONResultParser.Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeJSONQueryResult(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(``))
+		if _, err := DecodeJSONQueryResult(reader); err != io.EOF {
+			t.Errorf("DecodeJSONQueryResult() error = %v", err)
+		}
+	})
+	t.Run("not an object", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(`[]`))
+		if _, err := DecodeJSONQueryResult(reader); err == nil {
+			t.Errorf("DecodeJSONQueryResult() error = %v", err)
+		}
+	})
+	t.Run("no head", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(`{"foo": 1}`))
+		if _, err := DecodeJSONQueryResult(reader); err != io.EOF {
+			t.Errorf("DecodeJSONQueryResult() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(`{"head": {}}`))
+		got, err := DecodeJSONQueryResult(reader)
+		if err != nil {
+			t.Errorf("DecodeJSONQueryResult() error = %v", err)
+			return
+		}
+		if got, want := got.Variables(), []string{}; !reflect.DeepEqual(got, want) {
+			t.Errorf("DecodeJSONQueryResult() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestJSONQueryResult_Next(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {"vars": []}, "results": {"bindings": []}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("bad JSON", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {"vars": ["x"]}, "results": {"bindings": [{"x": }]}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = result.Next()
+		if _, ok := err.(*json.SyntaxError); !ok {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("unknown binding", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {"vars": ["x"]}, "results": {"bindings": [{"x": {"type": "foo", "value": "bar"}}]}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := result.Next(); err == nil {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(`{
+  "head": {"vars": ["x", "hpage", "name", "age", "mbox"], "link": []},
+  "results": {
+    "distinct": false,
+    "bindings": [
+      {
+        "x": {"type": "bnode", "value": "r2"},
+        "hpage": {"type": "uri", "value": "http://work.example.org/bob/"},
+        "name": {"type": "literal", "value": "Bob", "xml:lang": "en"},
+        "age": {"type": "literal", "value": "30", "datatype": "http://www.w3.org/2001/XMLSchema#integer"},
+        "mbox": {"type": "uri", "value": "mailto:bob@work.example.org"}
+      },
+      {
+        "x": {"type": "typed-literal", "value": "1", "datatype": "http://www.w3.org/2001/XMLSchema#integer"}
+      }
+    ],
+    "ordered": false
+  }
+}`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := result.Variables(), []string{"x", "hpage", "name", "age", "mbox"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("JSONQueryResult.Variables() = %v, want %v", got, want)
+		}
+
+		got, err := result.Next()
+		if err != nil {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+			return
+		}
+		want := map[string]Value{
+			"x":     BNode("r2"),
+			"hpage": URI("http://work.example.org/bob/"),
+			"name": Literal{
+				Value:       "Bob",
+				LanguageTag: "en",
+			},
+			"age": Literal{
+				Value:    "30",
+				DataType: URI("http://www.w3.org/2001/XMLSchema#integer"),
+			},
+			"mbox": URI("mailto:bob@work.example.org"),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("JSONQueryResult.Next() = %v, want %v", got, want)
+		}
+
+		got, err = result.Next()
+		if err != nil {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+			return
+		}
+		want = map[string]Value{
+			"x": Literal{
+				Value:    "1",
+				DataType: URI("http://www.w3.org/2001/XMLSchema#integer"),
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("JSONQueryResult.Next() = %v, want %v", got, want)
+		}
+
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("results before head", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"results": {"bindings": [{"x": {"type": "uri", "value": "http://example.com/a"}}, {}]}, ` +
+				`"head": {"vars": ["x"]}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := result.Variables(), []string{"x"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("JSONQueryResult.Variables() = %v, want %v", got, want)
+		}
+		for _, want := range []map[string]Value{{"x": URI("http://example.com/a")}, {}} {
+			got, err := result.Next()
+			if err != nil {
+				t.Fatalf("JSONQueryResult.Next() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("JSONQueryResult.Next() = %v, want %v", got, want)
+			}
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("JSONQueryResult.Next() error = %v", err)
+		}
+	})
+}
+
+func TestJSONQueryResult_Boolean(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {}, "results": {"bindings": []}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := result.Boolean(); err != io.EOF {
+			t.Errorf("JSONQueryResult.Boolean() error = %v", err)
+		}
+	})
+	t.Run("bad JSON", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {}, "boolean": tru}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = result.Boolean()
+		if _, ok := err.(*json.SyntaxError); !ok {
+			t.Errorf("JSONQueryResult.Boolean() error = %v", err)
+		}
+	})
+	t.Run("after head", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"head": {}, "boolean": true}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := result.Boolean()
+		if err != nil {
+			t.Errorf("JSONQueryResult.Boolean() error = %v", err)
+			return
+		}
+		if want := true; got != want {
+			t.Errorf("JSONQueryResult.Boolean() = %v, want %v", got, want)
+		}
+	})
+	t.Run("before head", func(t *testing.T) {
+		result, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(
+			`{"boolean": true, "head": {}}`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := result.Boolean()
+		if err != nil {
+			t.Errorf("JSONQueryResult.Boolean() error = %v", err)
+			return
+		}
+		if want := true; got != want {
+			t.Errorf("JSONQueryResult.Boolean() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestJSONQueryResult_Close(t *testing.T) {
+	x := &JSONQueryResult{
+		r: ioutil.NopCloser(strings.NewReader("")),
+	}
+	if err := x.Close(); err != nil {
+		t.Errorf("JSONQueryResult.Close() error = %v", err)
+	}
+}
+
+// nolint: scopelint
+func TestJSONQueryResult_Form(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want parser.Form
+	}{
+		{name: "ask", doc: `{"head": {}, "boolean": false}`, want: parser.FormAsk},
+		{name: "boolean before head", doc: `{"boolean": false, "head": {}}`, want: parser.FormAsk},
+		{name: "select", doc: `{"head": {"vars": ["s"]}, "results": {"bindings": []}}`, want: parser.FormSelect},
+		{name: "head only", doc: `{"head": {}}`, want: parser.FormSelect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(tt.doc)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := x.(FormDetector).Form()
+			if err != nil {
+				t.Fatalf("JSONQueryResult.Form() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("JSONQueryResult.Form() = %v, want %v", got, tt.want)
+			}
+			if got == parser.FormAsk {
+				if b, err := x.Boolean(); err != nil || b {
+					t.Errorf("JSONQueryResult.Boolean() = %v, %v", b, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"io"
+)
+
+// NQuadsParser is the parser for N-Quads-formatted RDF datasets.
+type NQuadsParser struct {
+}
+
+// NewNQuadsParser returns `NQuadsParser` as the implementation of `QuadParser`.
+func NewNQuadsParser() QuadParser {
+	return &NQuadsParser{}
+}
+
+// Parse parses the N-Quads stream.
+func (*NQuadsParser) Parse(r io.ReadCloser) (QuadResult, error) {
+	return DecodeNQuads(r)
+}
+
+// Format returns a media type. It's used as an `Accept` request header value.
+func (*NQuadsParser) Format() string {
+	return "application/n-quads"
+}
+
+// DecodeNQuads decodes responded N-Quads document.
+// https://www.w3.org/TR/n-quads/
+func DecodeNQuads(r io.ReadCloser) (QuadResult, error) {
+	parser := newTurtleParser(r)
+	parser.nquads = true
+	return &TriGQuadResult{
+		r:      r,
+		parser: parser,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNQuadsParser_Format(t *testing.T) {
+	if got, want := NewNQuadsParser().Format(), "application/n-quads"; got != want {
+		t.Errorf("NQuadsParser.Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeNQuads(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := NewNQuadsParser().Parse(ioutil.NopCloser(strings.NewReader(`# comment
+<http://example.com/s> <http://example.com/p> <http://example.com/o> .
+<http://example.com/s> <http://example.com/p> "foo"@en <http://example.com/g> .
+_:b0 <http://example.com/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> _:g .
+`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := decodeQuads(t, result)
+		if err != nil {
+			t.Errorf("DecodeNQuads() error = %v", err)
+			return
+		}
+		s, p := URI("http://example.com/s"), URI("http://example.com/p")
+		want := []Quad{
+			{Triple: Triple{s, p, URI("http://example.com/o")}},
+			{Triple: Triple{s, p, Literal{Value: "foo", LanguageTag: "en"}}, Graph: URI("http://example.com/g")},
+			{Triple: Triple{BNode("b0"), p, Literal{Value: "1", DataType: URI(xsdInteger)}}, Graph: BNode("g")},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DecodeNQuads() = %v, want %v", got, want)
+		}
+	})
+	t.Run("missing dot", func(t *testing.T) {
+		result, err := DecodeNQuads(ioutil.NopCloser(strings.NewReader(
+			`<http://example.com/s> <http://example.com/p> <http://example.com/o> <http://example.com/g>`,
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decodeQuads(t, result); err == nil {
+			t.Errorf("DecodeNQuads() error = %v", err)
+		}
+	})
+}
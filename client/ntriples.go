@@ -0,0 +1,32 @@
+package client
+
+import (
+	"io"
+)
+
+// NTriplesParser is the parser for N-Triples-formatted RDF graphs.
+type NTriplesParser struct {
+}
+
+// NewNTriplesParser returns `NTriplesParser` as the implementation of `GraphParser`.
+func NewNTriplesParser() GraphParser {
+	return &NTriplesParser{}
+}
+
+// Parse parses the N-Triples stream.
+func (*NTriplesParser) Parse(r io.ReadCloser) (GraphResult, error) {
+	return DecodeNTriples(r)
+}
+
+// Format returns a media type. It's used as an `Accept` request header value.
+func (*NTriplesParser) Format() string {
+	return "application/n-triples"
+}
+
+// DecodeNTriples decodes responded N-Triples document.
+// https://www.w3.org/TR/n-triples/
+//
+// N-Triples is a subset of Turtle, so it's decoded by the Turtle decoder.
+func DecodeNTriples(r io.ReadCloser) (GraphResult, error) {
+	return DecodeTurtle(r)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNTriplesParser_Format(t *testing.T) {
+	if got, want := NewNTriplesParser().Format(), "application/n-triples"; got != want {
+		t.Errorf("NTriplesParser.Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeNTriples(t *testing.T) {
+	result, err := NewNTriplesParser().Parse(ioutil.NopCloser(strings.NewReader(`# comment
+<http://example.com/s> <http://example.com/p> <http://example.com/o> .
+_:b0 <http://example.com/p> "foo"@en .
+_:b0 <http://example.com/p> "1"^^<http://www.w3.org/2001/XMLSchema#integer> . # comment
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+
+	want := []Triple{
+		{URI("http://example.com/s"), URI("http://example.com/p"), URI("http://example.com/o")},
+		{BNode("b0"), URI("http://example.com/p"), Literal{Value: "foo", LanguageTag: "en"}},
+		{BNode("b0"), URI("http://example.com/p"), Literal{Value: "1", DataType: URI(xsdInteger)}},
+	}
+	for _, w := range want {
+		got, err := result.Next()
+		if err != nil {
+			t.Errorf("NTriplesGraphResult.Next() error = %v", err)
+			return
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("NTriplesGraphResult.Next() = %v, want %v", got, w)
+		}
+	}
+	if _, err := result.Next(); err != io.EOF {
+		t.Errorf("NTriplesGraphResult.Next() error = %v", err)
+	}
+}
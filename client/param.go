@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/garsue/sparql/parser"
 )
 
 const dateTimeFormat = `"2006-01-02T15:04:05Z07:00"^^xsd:dateTime`
@@ -40,22 +42,70 @@ type Serializable interface {
 	Serialize() string
 }
 
+// stringEscaper escapes backslashes and quotes so that any string can be
+// embedded in a long string literal.
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote returns the long string literal of s.
+func quote(s string) string {
+	return strings.Join([]string{`"""`, stringEscaper.Replace(s), `"""`}, "")
+}
+
 // Serialize returns the serialized literal string.
 func (l Literal) Serialize() string {
-	s := fmt.Sprint(l.Value)
-	s = strings.Replace(s, `"""`, `\"\"\"`, -1)
+	s := quote(fmt.Sprint(l.Value))
 	if l.LanguageTag != "" {
-		return strings.Join([]string{`"""`, s, `"""@`, l.LanguageTag}, "")
+		return s + "@" + l.LanguageTag
 	}
 	if l.DataType != nil {
-		return strings.Join([]string{`"""`, s, `"""^^`, l.DataType.Ref()}, "")
+		return s + "^^" + l.DataType.Ref()
 	}
-	return strings.Join([]string{`"""`, s, `"""`}, "")
+	return s
+}
+
+// Validate returns an error if the value has a part which cannot be written in a query
+// as it is: a malformed language tag or prefixed name of a literal, a malformed
+// prefixed name or a malformed blank node label. `Serialize` doesn't check them.
+func (p Param) Validate() error {
+	value := p.Value
+	if literal, ok := EncodeLiteral(value); ok {
+		value = literal
+	}
+	switch v := value.(type) {
+	case Literal:
+		if v.LanguageTag != "" {
+			if !parser.IsLanguageTag(v.LanguageTag) {
+				return fmt.Errorf("invalid language tag %q", v.LanguageTag)
+			}
+			return nil
+		}
+		if name, ok := v.DataType.(PrefixedName); ok {
+			return validatePrefixedName(name)
+		}
+	case PrefixedName:
+		return validatePrefixedName(v)
+	case BNode:
+		if !parser.IsBlankNodeLabel(string(v)) {
+			return fmt.Errorf("invalid blank node label %q", string(v))
+		}
+	}
+	return nil
+}
+
+func validatePrefixedName(name PrefixedName) error {
+	if !parser.IsPrefixedName(string(name)) {
+		return fmt.Errorf("invalid prefixed name %q", string(name))
+	}
+	return nil
 }
 
 // Serialize returns the serialized as query parameter.
+// Values handled by the encoders registered with `RegisterDatatype` are typed literals.
 // nolint: gocyclo
 func (p Param) Serialize() string {
+	if literal, ok := EncodeLiteral(p.Value); ok {
+		return literal.Serialize()
+	}
 	switch v := p.Value.(type) {
 	case int:
 		return strconv.Itoa(v)
@@ -84,20 +134,18 @@ func (p Param) Serialize() string {
 	case bool:
 		return strconv.FormatBool(v)
 	case []byte:
-		s := strings.Replace(string(v), `"""`, `\"\"\"`, -1)
-		return strings.Join([]string{`"""`, s, `"""`}, "")
+		return quote(string(v))
 	case string:
-		s := strings.Replace(v, `"""`, `\"\"\"`, -1)
-		return strings.Join([]string{`"""`, s, `"""`}, "")
+		return quote(v)
 	case time.Time:
 		return v.Format(dateTimeFormat)
 	case IRIRef:
 		return v.Ref()
+	case BNode:
+		return "_:" + string(v)
 	case Serializable:
 		return v.Serialize()
 	default:
-		s := fmt.Sprint(v)
-		s = strings.Replace(s, `"""`, `\"\"\"`, -1)
-		return strings.Join([]string{`"""`, s, `"""`}, "")
+		return quote(fmt.Sprint(v))
 	}
 }
@@ -185,6 +185,13 @@ func TestParam_Serialize(t *testing.T) {
 			},
 			want: `<foo>`,
 		},
+		{
+			name: "BNode",
+			fields: fields{
+				Value: BNode("b1"),
+			},
+			want: `_:b1`,
+		},
 		{
 			name: "Serializable",
 			fields: fields{
@@ -212,3 +219,49 @@ func TestParam_Serialize(t *testing.T) {
 		})
 	}
 }
+
+// nolint: scopelint
+func TestParam_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{name: "string", value: "x . } ; DROP ALL #"},
+		{name: "blank node", value: BNode("b1")},
+		{name: "bad blank node", value: BNode("x . }"), wantErr: `invalid blank node label "x . }"`},
+		{name: "language tag", value: Literal{Value: "a", LanguageTag: "en-US"}},
+		{
+			name:    "bad language tag",
+			value:   Literal{Value: "a", LanguageTag: "en . } ; DROP ALL #"},
+			wantErr: `invalid language tag "en . } ; DROP ALL #"`,
+		},
+		{name: "prefixed datatype", value: Literal{Value: "1", DataType: PrefixedName("xsd:integer")}},
+		{
+			name:    "bad prefixed datatype",
+			value:   Literal{Value: "1", DataType: PrefixedName("xsd:integer . } ; DROP ALL #")},
+			wantErr: `invalid prefixed name "xsd:integer . } ; DROP ALL #"`,
+		},
+		{name: "URI datatype", value: Literal{Value: "1", DataType: URI("http://example.com/> . }")}},
+		{name: "prefixed name", value: PrefixedName("foaf:name")},
+		{name: "bad prefixed name", value: PrefixedName("foaf:name }"), wantErr: `invalid prefixed name "foaf:name }"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Param{Ordinal: 1, Value: tt.value}.Validate()
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Param.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_quote(t *testing.T) {
+	for _, s := range []string{``, `"`, `foo"`, `""""`, `a\nb`, `\`, "line\nbreak", `'''`} {
+		quoted := quote(s)
+		got, rest, err := unquote(quoted)
+		if err != nil || rest != "" || got != s {
+			t.Errorf("quote(%q) = %s, unquoted %q %q %v", s, quoted, got, rest, err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// placeholder is a placeholder in a query. key is `$n` or `@name`.
+type placeholder struct {
+	key        string
+	start, end int
+}
+
+// scanPlaceholders finds `$n` and `@name` placeholders in term positions of the query.
+// String literals, IRIs, comments and language tags are skipped.
+// `$` followed by a variable name other than digits is a SPARQL variable, not a placeholder.
+// nolint: gocyclo
+func scanPlaceholders(query string) []placeholder {
+	var placeholders []placeholder
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '#':
+			i = skipComment(query, i)
+		case c == '"' || c == '\'':
+			i = skipLanguageTag(query, skipString(query, i))
+		case c == '<':
+			i = skipIRI(query, i)
+		case c == '?' || c == '$':
+			end := skipVarName(query, i+1)
+			if c == '$' && end > i+1 && isDigits(query[i+1:end]) {
+				placeholders = append(placeholders, placeholder{key: query[i:end], start: i, end: end})
+			}
+			i = end
+		case c == '@':
+			end := skipVarName(query, i+1)
+			if end > i+1 {
+				placeholders = append(placeholders, placeholder{key: query[i:end], start: i, end: end})
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return placeholders
+}
+
+// substitute writes the query replacing the placeholders with the serialized parameters.
+// It returns an error for a placeholder without the parameter and a parameter used by
+// no placeholder.
+func substitute(writer io.Writer, query string, placeholders []placeholder, params []Param) error {
+	indexes := make(map[string]int, 2*len(params))
+	values := make([]string, 0, len(params))
+	for i, p := range params {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		values = append(values, p.Serialize())
+		for _, key := range p.Placeholders() {
+			indexes[key] = i
+		}
+	}
+	for _, p := range placeholders {
+		if _, ok := indexes[p.key]; !ok {
+			return fmt.Errorf("missing parameter for placeholder %s", p.key)
+		}
+	}
+	used := make([]bool, len(params))
+	for _, p := range placeholders {
+		used[indexes[p.key]] = true
+	}
+	for i, ok := range used {
+		if !ok {
+			return fmt.Errorf("unused parameter %s", params[i].Placeholders()[0])
+		}
+	}
+
+	last := 0
+	for _, p := range placeholders {
+		v := values[indexes[p.key]]
+		if _, err := io.WriteString(writer, query[last:p.start]); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(writer, v); err != nil {
+			return err
+		}
+		last = p.end
+	}
+	_, err := io.WriteString(writer, query[last:])
+	return err
+}
+
+func skipComment(query string, i int) int {
+	if j := strings.IndexByte(query[i:], '\n'); j >= 0 {
+		return i + j + 1
+	}
+	return len(query)
+}
+
+// skipString skips a short or long string literal and returns the index after the closing quote.
+func skipString(query string, i int) int {
+	quote := query[i : i+1]
+	if strings.HasPrefix(query[i:], quote+quote+quote) {
+		quote = quote + quote + quote
+	}
+	for j := i + len(quote); j < len(query); j++ {
+		if query[j] == '\\' {
+			j++
+			continue
+		}
+		if strings.HasPrefix(query[j:], quote) {
+			return j + len(quote)
+		}
+	}
+	return len(query)
+}
+
+// skipLanguageTag skips the language tag following a string literal.
+func skipLanguageTag(query string, i int) int {
+	j := i
+	for j < len(query) && isSpace(query[j]) {
+		j++
+	}
+	if j >= len(query) || query[j] != '@' {
+		return i
+	}
+	j++
+	for j < len(query) && (isAlnum(query[j]) || query[j] == '-') {
+		j++
+	}
+	return j
+}
+
+// skipIRI skips an IRIREF. `<` which doesn't start an IRIREF is an operator.
+func skipIRI(query string, i int) int {
+	for j := i + 1; j < len(query); j++ {
+		switch c := query[j]; {
+		case c == '>':
+			return j + 1
+		case c <= ' ' || strings.IndexByte("<\"{}|^`\\", c) >= 0:
+			return i + 1
+		}
+	}
+	return i + 1
+}
+
+// skipVarName returns the index after the variable name starting at i.
+func skipVarName(query string, i int) int {
+	for i < len(query) && (isAlnum(query[i]) || query[i] == '_' || query[i] >= 0x80) {
+		i++
+	}
+	return i
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
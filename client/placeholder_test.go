@@ -0,0 +1,58 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// nolint: scopelint
+func Test_scanPlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []placeholder
+	}{
+		{
+			name:  "none",
+			query: "SELECT * WHERE { ?s ?p ?o }",
+		},
+		{
+			name:  "ordinal and named",
+			query: "ASK { $1 ?p @name }",
+			want: []placeholder{
+				{key: "$1", start: 6, end: 8},
+				{key: "@name", start: 12, end: 17},
+			},
+		},
+		{
+			name:  "variables",
+			query: "SELECT $s $1a ?1 WHERE {}",
+		},
+		{
+			name:  "long strings",
+			query: `ASK { ?s ?p """a "b" $1""" , "\"$1" , '\'$1' }`,
+		},
+		{
+			name:  "unterminated string",
+			query: `ASK { ?s ?p "$1 }`,
+		},
+		{
+			name:  "unterminated IRI",
+			query: `ASK { ?s ?p <$1`,
+			want: []placeholder{
+				{key: "$1", start: 13, end: 15},
+			},
+		},
+		{
+			name:  "comment at the end",
+			query: `ASK {} # $1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanPlaceholders(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scanPlaceholders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package client
 
 import (
-	"bufio"
 	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+
+	"github.com/garsue/sparql/parser"
 )
 
 // Query queries to the endpoint.
@@ -21,9 +24,12 @@ func (c *Client) Query(
 
 // Statement is prepared statement.
 type Statement struct {
-	c      *Client
-	query  string
-	prefix string
+	c            *Client
+	query        string
+	prefix       string
+	placeholders []placeholder
+	// err is the validation error found by `Client.Prepare`.
+	err error
 }
 
 // Prepare returns `*sparql.Statement`.
@@ -37,51 +43,123 @@ func (c *Client) Prepare(query string) *Statement {
 		ss = append(ss, uri.Ref())
 		ss = append(ss, "\n")
 	}
-	return &Statement{c: c, prefix: strings.Join(ss, ""), query: query}
+	s := &Statement{
+		c:            c,
+		prefix:       strings.Join(ss, ""),
+		query:        query,
+		placeholders: scanPlaceholders(query),
+	}
+	if c.validate {
+		s.err = s.Validate()
+	}
+	return s
+}
+
+// NumInput returns the number of parameters the statement needs.
+// It's the largest n of `$n` placeholders or the number of distinct `@name` placeholders.
+// It returns -1 if both kinds are used because a parameter can fill both of them.
+func (s *Statement) NumInput() int {
+	maxOrdinal := 0
+	names := make(map[string]struct{})
+	for _, p := range s.placeholders {
+		if strings.HasPrefix(p.key, "@") {
+			names[p.key] = struct{}{}
+			continue
+		}
+		if n, err := strconv.Atoi(p.key[1:]); err == nil && n > maxOrdinal {
+			maxOrdinal = n
+		}
+	}
+	switch {
+	case maxOrdinal > 0 && len(names) > 0:
+		return -1
+	case len(names) > 0:
+		return len(names)
+	default:
+		return maxOrdinal
+	}
 }
 
 // Query queries to the endpoint.
+// The returned `QueryResult` owns the response body. Close it to release the connection.
 func (s *Statement) Query(
 	ctx context.Context,
 	params ...Param,
-) (_ QueryResult, err error) {
+) (QueryResult, error) {
+	if err := s.validated("Query", parser.FormSelect, parser.FormAsk); err != nil {
+		return nil, err
+	}
 	request, err := s.request(ctx, params...)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.c.HTTPClient.Do(request)
+	resp, err := s.do(request, params)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err2 := resp.Body.Close(); err2 != nil && err == nil {
-			err = err2
-		}
-	}()
 
-	if resp.StatusCode != http.StatusOK {
-		scanner := bufio.NewScanner(resp.Body)
-		var errMsg string
-		if scanner.Scan() {
-			errMsg = scanner.Text()
+	body := &responseBody{ReadCloser: resp.Body, ctx: ctx}
+	result, err := s.c.resultParser.Parse(body)
+	if err != nil {
+		_ = body.Close()
+		return nil, contextError(ctx, err)
+	}
+	return &contextResult{QueryResult: result, ctx: ctx}, nil
+}
+
+// do sends the request and returns the response if it succeeded.
+// It returns `*HTTPError` for the other status codes.
+func (s *Statement) do(request *http.Request, params []Param) (*http.Response, error) {
+	return s.c.send(request, false, func(resp *http.Response) error {
+		if resp.StatusCode == http.StatusOK {
+			return nil
 		}
-		return nil, fmt.Errorf(
-			"SPARQL query error. status code: %d msg: %s",
-			resp.StatusCode,
-			errMsg,
-		)
+		return s.c.newHTTPError("query", s.c.Endpoint, s.text(params), resp)
+	})
+}
+
+// text returns the statement with the parameters as sent.
+// It's empty if the parameters cannot be written.
+func (s *Statement) text(params []Param) string {
+	var b strings.Builder
+	if err := s.compose(&b, params...); err != nil {
+		return ""
 	}
+	return b.String()
+}
+
+// maxDrainSize is the max size of the unread response body to be discarded on close.
+// Larger bodies are just closed and the connection is not reused.
+const maxDrainSize = 256 << 10
 
-	return s.c.resultParser.Parse(resp.Body)
+// responseBody drains the rest of the body on close to reuse the connection.
+// The body is just closed without draining if the request context is done.
+type responseBody struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+// Close drains and closes the body.
+func (b *responseBody) Close() error {
+	if b.ctx.Err() == nil {
+		_, _ = io.CopyN(ioutil.Discard, b.ReadCloser, maxDrainSize)
+	}
+	return b.ReadCloser.Close()
 }
 
 func (s *Statement) request(ctx context.Context, params ...Param) (*http.Request, error) {
+	return s.newRequest(ctx, s.c.resultParser.Format(), params...)
+}
+
+// newRequest builds a query request. The `format` parameter is omitted if format is empty.
+func (s *Statement) newRequest(ctx context.Context, format string, params ...Param) (*http.Request, error) {
 	request, err := http.NewRequest(http.MethodGet, s.c.Endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	request = request.WithContext(ctx)
+	s.c.setCredentials(request)
 
 	const defaultBufferSize = 1024
 	b := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
@@ -92,27 +170,54 @@ func (s *Statement) request(ctx context.Context, params ...Param) (*http.Request
 
 	// Build the query
 	built := b.String()
-	url := request.URL.Query()
-	url.Set("query", built)
-	url.Set("format", s.c.resultParser.Format())
-	request.URL.RawQuery = url.Encode()
+	values := request.URL.Query()
+	if format != "" {
+		values.Set("format", format)
+	}
+	switch s.c.queryMethod {
+	case QueryPostForm:
+		setFormBody(request, values, built)
+	case QueryPostDirect:
+		request.Method = http.MethodPost
+		request.URL.RawQuery = values.Encode()
+		request.Header.Set("Content-Type", "application/sparql-query")
+		setBody(request, built)
+	case QueryAuto:
+		values.Set("query", built)
+		request.URL.RawQuery = values.Encode()
+		if len(request.URL.String()) > s.c.maxURLLength() {
+			setFormBody(request, values, built)
+		}
+	default:
+		values.Set("query", built)
+		request.URL.RawQuery = values.Encode()
+	}
 	return request, nil
 }
 
+// setFormBody makes the request an URL-encoded POST which contains the query and the other parameters.
+func setFormBody(request *http.Request, form url.Values, query string) {
+	form.Set("query", query)
+	request.Method = http.MethodPost
+	request.URL.RawQuery = ""
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	setBody(request, form.Encode())
+}
+
+func setBody(request *http.Request, body string) {
+	request.Body = ioutil.NopCloser(strings.NewReader(body))
+	request.ContentLength = int64(len(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(body)), nil
+	}
+}
+
+// compose writes the prefix and the query with the parameters.
+// Placeholders are found in term positions by `Client.Prepare`. See `scanPlaceholders`.
 func (s *Statement) compose(writer io.Writer, params ...Param) error {
 	// Write prefix
-	if _, err := writer.Write([]byte(s.prefix)); err != nil {
+	if _, err := io.WriteString(writer, s.prefix); err != nil {
 		return err
 	}
-	// Replace parameters
-	replacePairs := make([]string, 0, 2*len(params))
-	for _, p := range params {
-		v := p.Serialize()
-		for _, key := range p.Placeholders() {
-			replacePairs = append(replacePairs, key, v)
-		}
-	}
-
-	_, err := strings.NewReplacer(replacePairs...).WriteString(writer, s.query)
-	return err
+	return substitute(writer, s.query, s.placeholders, params)
 }
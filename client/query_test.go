@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -403,8 +406,8 @@ func TestClient_Query(t *testing.T) {
 			prefixes:     map[string]URI{"foo": "bar"},
 			resultParser: NewXMLResultParser(),
 		}
-		result, err := c.Query(context.Background(), "", Param{
-			Ordinal: 0,
+		result, err := c.Query(context.Background(), "SELECT ?x WHERE { ?x ?p $1 }", Param{
+			Ordinal: 1,
 			Value:   1,
 		})
 		if err != nil {
@@ -446,15 +449,41 @@ func TestClient_Prepare(t *testing.T) {
 		}
 		want := &Statement{
 			c:      &c,
-			query:  "query",
+			query:  "query $1",
 			prefix: "PREFIX foo: <http://example.com>\n",
+			placeholders: []placeholder{
+				{key: "$1", start: 6, end: 8},
+			},
 		}
-		if got := c.Prepare("query"); !reflect.DeepEqual(got, want) {
+		if got := c.Prepare("query $1"); !reflect.DeepEqual(got, want) {
 			t.Errorf("Client.Prepare() = %+v, want %+v", got, want)
 		}
 	})
 }
 
+// nolint: scopelint
+func TestStatement_NumInput(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "ASK {}", want: 0},
+		{query: "ASK { $1 ?p $2 . $1 ?q ?o }", want: 2},
+		{query: "ASK { ?s ?p $3 }", want: 3},
+		{query: "ASK { @s ?p @o . @s ?q ?o }", want: 2},
+		{query: "ASK { @s ?p $1 }", want: -1},
+		{query: `ASK { ?s ?p "$1" }`, want: 0},
+	}
+	var c Client
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := c.Prepare(tt.query).NumInput(); got != tt.want {
+				t.Errorf("Statement.NumInput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestStatement_Query(t *testing.T) {
 	t.Run("request error", func(t *testing.T) {
 		c, err := New("foo")
@@ -523,8 +552,8 @@ func TestStatement_Query(t *testing.T) {
 			prefixes:     map[string]URI{"foo": "bar"},
 			resultParser: NewXMLResultParser(),
 		}
-		result, err := c.Prepare("").Query(context.Background(), Param{
-			Ordinal: 0,
+		result, err := c.Prepare("SELECT ?x WHERE { ?x ?p $1 }").Query(context.Background(), Param{
+			Ordinal: 1,
 			Value:   1,
 		})
 		if err != nil {
@@ -549,6 +578,293 @@ func TestStatement_Query(t *testing.T) {
 	})
 }
 
+// nolint: scopelint
+func TestStatement_request(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          QueryMethod
+		maxURLLength    int
+		query           string
+		wantMethod      string
+		wantContentType string
+		wantRawQuery    string
+		wantBody        string
+	}{
+		{
+			name:         "get",
+			method:       QueryGet,
+			query:        "ASK {}",
+			wantMethod:   http.MethodGet,
+			wantRawQuery: "format=application%2Fsparql-results%2Bxml&graph=g&query=ASK+%7B%7D",
+		},
+		{
+			name:            "post form",
+			method:          QueryPostForm,
+			query:           "ASK {}",
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "format=application%2Fsparql-results%2Bxml&graph=g&query=ASK+%7B%7D",
+		},
+		{
+			name:            "post direct",
+			method:          QueryPostDirect,
+			query:           "ASK {}",
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/sparql-query",
+			wantRawQuery:    "format=application%2Fsparql-results%2Bxml&graph=g",
+			wantBody:        "ASK {}",
+		},
+		{
+			name:         "auto short",
+			method:       QueryAuto,
+			query:        "ASK {}",
+			wantMethod:   http.MethodGet,
+			wantRawQuery: "format=application%2Fsparql-results%2Bxml&graph=g&query=ASK+%7B%7D",
+		},
+		{
+			name:            "auto long",
+			method:          QueryAuto,
+			maxURLLength:    80,
+			query:           "ASK { ?s ?p ?o }",
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "format=application%2Fsparql-results%2Bxml&graph=g&query=ASK+%7B+%3Fs+%3Fp+%3Fo+%7D",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				Endpoint:     "http://example.com/sparql?graph=g",
+				resultParser: NewXMLResultParser(),
+				queryMethod:  tt.method,
+				urlLength:    tt.maxURLLength,
+			}
+			got, err := c.Prepare(tt.query).request(context.Background())
+			if err != nil {
+				t.Errorf("Statement.request() error = %v", err)
+				return
+			}
+			if got.Method != tt.wantMethod {
+				t.Errorf("Statement.request() method = %v, want %v", got.Method, tt.wantMethod)
+			}
+			if got := got.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Statement.request() Content-Type = %v, want %v", got, tt.wantContentType)
+			}
+			if got.URL.RawQuery != tt.wantRawQuery {
+				t.Errorf("Statement.request() query = %v, want %v", got.URL.RawQuery, tt.wantRawQuery)
+			}
+			var body []byte
+			if got.Body != nil {
+				if body, err = ioutil.ReadAll(got.Body); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Statement.request() body = %v, want %v", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+// writeRows writes a query result document with n rows in the format.
+func writeRows(w io.Writer, format string, n int) {
+	switch format {
+	case "json":
+		_, _ = fmt.Fprint(w, `{"head": {"vars": ["x"]}, "results": {"bindings": [`)
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				_, _ = fmt.Fprint(w, ",")
+			}
+			_, _ = fmt.Fprintf(w, `{"x": {"type": "uri", "value": "http://example.com/resource/%08d"}}`, i)
+		}
+		_, _ = fmt.Fprint(w, `]}}`)
+	default:
+		_, _ = fmt.Fprint(w, `<sparql><head><variable name="x"/></head><results>`)
+		for i := 0; i < n; i++ {
+			_, _ = fmt.Fprintf(w,
+				`<result><binding name="x"><uri>http://example.com/resource/%08d</uri></binding></result>`, i)
+		}
+		_, _ = fmt.Fprint(w, `</results></sparql>`)
+	}
+}
+
+func TestStatement_Query_streaming(t *testing.T) {
+	const rows = 50000
+	parsers := map[string]ResultParser{
+		"xml":  NewXMLResultParser(),
+		"json": NewJSONResultParser(),
+	}
+	for format, parser := range parsers {
+		format, parser := format, parser
+		t.Run(format, func(t *testing.T) {
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					// Send the first row and wait until the client reads it.
+					pr, pw := io.Pipe()
+					go func() {
+						writeRows(pw, format, rows)
+						_ = pw.Close()
+					}()
+					_, _ = io.CopyN(w, pr, 4096)
+					w.(http.Flusher).Flush()
+					select {
+					case <-release:
+					case <-time.After(5 * time.Second):
+					}
+					_, _ = io.Copy(w, pr)
+				},
+			))
+			defer server.Close()
+
+			c, err := New(server.URL, WithResultParser(parser))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := c.Query(context.Background(), "")
+			if err != nil {
+				t.Fatalf("Client.Query() error = %v", err)
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := result.Next()
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("QueryResult.Next() error = %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("QueryResult.Next() waited for the whole body")
+			}
+			close(release)
+
+			n := 1
+			for {
+				if _, err := result.Next(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("QueryResult.Next() error = %v", err)
+				}
+				n++
+			}
+			if n != rows {
+				t.Errorf("rows = %d, want %d", n, rows)
+			}
+			if err := result.Close(); err != nil {
+				t.Errorf("QueryResult.Close() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStatement_Query_cancel(t *testing.T) {
+	parsers := map[string]ResultParser{
+		"xml":  NewXMLResultParser(),
+		"json": NewJSONResultParser(),
+		"tsv":  NewTSVResultParser(),
+	}
+	for format, parser := range parsers {
+		format, parser := format, parser
+		t.Run(format, func(t *testing.T) {
+			stop := make(chan struct{})
+			defer close(stop)
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					// Send a part of the result and stall.
+					switch format {
+					case "json":
+						_, _ = fmt.Fprint(w, `{"head": {"vars": ["x"]}, "results": {"bindings": [{"x": {"type": "bnode", "value": "b0"}},`)
+					case "tsv":
+						_, _ = fmt.Fprint(w, "?x\n_:b0\n")
+					default:
+						_, _ = fmt.Fprint(w, `<sparql><head><variable name="x"/></head><results>`+
+							`<result><binding name="x"><bnode>b0</bnode></binding></result>`)
+					}
+					w.(http.Flusher).Flush()
+					select {
+					case <-r.Context().Done():
+					case <-stop:
+					}
+				},
+			))
+			defer server.Close()
+
+			c, err := New(server.URL, WithResultParser(parser))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			result, err := c.Query(ctx, "")
+			if err != nil {
+				t.Fatalf("Client.Query() error = %v", err)
+			}
+			if _, err := result.Next(); err != nil {
+				t.Fatalf("QueryResult.Next() error = %v", err)
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := result.Next()
+				done <- err
+			}()
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+			select {
+			case err := <-done:
+				if err != context.Canceled {
+					t.Errorf("QueryResult.Next() error = %v, want %v", err, context.Canceled)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("QueryResult.Next() is not canceled")
+			}
+			if _, err := result.Next(); err != context.Canceled {
+				t.Errorf("QueryResult.Next() error = %v, want %v", err, context.Canceled)
+			}
+			_ = result.Close()
+		})
+	}
+}
+
+func TestStatement_Query_reuse(t *testing.T) {
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			writeRows(w, "xml", 10)
+		},
+	))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		result, err := c.Query(context.Background(), "")
+		if err != nil {
+			t.Fatalf("Client.Query() error = %v", err)
+		}
+		// Close without reading all rows.
+		if _, err := result.Next(); err != nil {
+			t.Fatalf("QueryResult.Next() error = %v", err)
+		}
+		if err := result.Close(); err != nil {
+			t.Fatalf("QueryResult.Close() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&conns); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
 func BenchmarkClient_request(b *testing.B) {
 	b.Run("query", func(b *testing.B) {
 		client, err := New("endpoint")
@@ -655,18 +971,106 @@ SELECT """Bob""" ?mbox WHERE { ?x foaf:name """Bob""" . ?x foaf:mbox ?mbox }`,
 SELECT """Bob""" ?mbox WHERE { ?x foaf:name """Bob""" . ?x foaf:mbox ?mbox }`,
 			wantErr: false,
 		},
+		{
+			name: "placeholders in literals, IRIs and comments",
+			fields: fields{
+				query: `SELECT * WHERE { <http://example.com/$1> ?p "$1 @name" , '''$1''' . # $1 @name
+?s ?p $1 . }`,
+			},
+			args: args{
+				params: []Param{{Name: "name", Ordinal: 1, Value: 1}},
+			},
+			wantWriter: `SELECT * WHERE { <http://example.com/$1> ?p "$1 @name" , '''$1''' . # $1 @name
+?s ?p 1 . }`,
+			wantErr: false,
+		},
+		{
+			name: "ten or more params",
+			fields: fields{
+				query: "SELECT * WHERE { ?s ?p $1 , $10 }",
+			},
+			args: args{
+				params: []Param{{Ordinal: 1, Value: 1}, {Ordinal: 10, Value: 10}},
+			},
+			wantWriter: "SELECT * WHERE { ?s ?p 1 , 10 }",
+			wantErr:    false,
+		},
+		{
+			name: "SPARQL variables and language tags",
+			fields: fields{
+				query: `SELECT $s WHERE { $s ?p "foo"@en , "bar" @ja ; ?q $1 FILTER(?o < $1) }`,
+			},
+			args: args{
+				params: []Param{{Ordinal: 1, Value: 1}},
+			},
+			wantWriter: `SELECT $s WHERE { $s ?p "foo"@en , "bar" @ja ; ?q 1 FILTER(?o < 1) }`,
+			wantErr:    false,
+		},
+		{
+			name: "missing ordinal param",
+			fields: fields{
+				query: "SELECT * WHERE { ?s ?p $2 }",
+			},
+			args: args{
+				params: []Param{{Ordinal: 1, Value: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing named param",
+			fields: fields{
+				query: "SELECT * WHERE { ?s ?p @foo }",
+			},
+			args: args{
+				params: []Param{{Name: "bar", Ordinal: 1, Value: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown named param",
+			fields: fields{
+				query: "SELECT * WHERE { ?s ?p @foo }",
+			},
+			args: args{
+				params: []Param{{Name: "foo", Ordinal: 1, Value: 1}, {Name: "bar", Ordinal: 2, Value: 2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown ordinal param",
+			fields: fields{
+				query: "SELECT * WHERE { ?s ?p $1 }",
+			},
+			args: args{
+				params: []Param{{Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid language tag",
+			fields: fields{
+				query: "INSERT DATA { <s> <p> $1 }",
+			},
+			args: args{
+				params: []Param{{Ordinal: 1, Value: Literal{Value: "x", LanguageTag: "en . } ; DROP ALL #"}}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Statement{
-				c:      tt.fields.c,
-				query:  tt.fields.query,
-				prefix: tt.fields.prefix,
+				c:            tt.fields.c,
+				query:        tt.fields.query,
+				prefix:       tt.fields.prefix,
+				placeholders: scanPlaceholders(tt.fields.query),
 			}
 			writer := &bytes.Buffer{}
 			if err := s.compose(writer, tt.args.params...); (err != nil) != tt.wantErr {
 				t.Errorf("Statement.compose() error = %v, wantErr %v", err, tt.wantErr)
 				return
+			} else if err != nil {
+				return
 			}
 			if gotWriter := writer.String(); gotWriter != tt.wantWriter {
 				t.Errorf("Statement.compose() = %v, want %v", gotWriter, tt.wantWriter)
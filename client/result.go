@@ -1,7 +1,10 @@
 package client
 
 import (
+	"context"
 	"io"
+
+	"github.com/garsue/sparql/parser"
 )
 
 // ResultParser is the parser for specific format query results.
@@ -21,6 +24,66 @@ type QueryResult interface {
 	io.Closer
 }
 
+// FormDetector is implemented by query results which tell the query form from the
+// result document before reading the bindings or the boolean.
+type FormDetector interface {
+	// Form returns `parser.FormAsk` for a boolean result and `parser.FormSelect` for bindings.
+	Form() (parser.Form, error)
+}
+
 // Value is an interface holding one of the binding (or boolean) types:
 // URI, Literal, BNode or bool.
 type Value interface{}
+
+// contextResult stops the iteration of `QueryResult` when the context is done.
+type contextResult struct {
+	QueryResult
+	ctx context.Context
+}
+
+// Next returns the context error instead of the next bindings once the context is done.
+func (r *contextResult) Next() (map[string]Value, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, timeoutError(err)
+	}
+	bindings, err := r.QueryResult.Next()
+	if err != nil {
+		return nil, contextError(r.ctx, err)
+	}
+	return bindings, nil
+}
+
+// Boolean returns the context error instead of the boolean once the context is done.
+func (r *contextResult) Boolean() (bool, error) {
+	if err := r.ctx.Err(); err != nil {
+		return false, timeoutError(err)
+	}
+	b, err := r.QueryResult.Boolean()
+	if err != nil {
+		return false, contextError(r.ctx, err)
+	}
+	return b, nil
+}
+
+// Form returns the form detected by the result. It's `parser.FormUnknown` if the
+// result is not a `FormDetector`.
+func (r *contextResult) Form() (parser.Form, error) {
+	d, ok := r.QueryResult.(FormDetector)
+	if !ok {
+		return parser.FormUnknown, nil
+	}
+	form, err := d.Form()
+	if err != nil {
+		return parser.FormUnknown, contextError(r.ctx, err)
+	}
+	return form, nil
+}
+
+// contextError prefers the context error to err, which is usually caused by the
+// aborted response body. Timeouts are wrapped with `*TimeoutError`.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return timeoutError(ctxErr)
+	}
+	return timeoutError(err)
+}
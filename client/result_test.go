@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/garsue/sparql/parser"
+)
+
+type mockQueryResult struct {
+	err error
+}
+
+func (m *mockQueryResult) Variables() []string {
+	return nil
+}
+
+func (m *mockQueryResult) Next() (map[string]Value, error) {
+	return map[string]Value{}, m.err
+}
+
+func (m *mockQueryResult) Boolean() (bool, error) {
+	return true, m.err
+}
+
+func (m *mockQueryResult) Close() error {
+	return nil
+}
+
+func Test_contextResult_Next(t *testing.T) {
+	readErr := errors.New("read error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("success", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{}, ctx: context.Background()}
+		if _, err := r.Next(); err != nil {
+			t.Errorf("contextResult.Next() error = %v", err)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{err: readErr}, ctx: context.Background()}
+		if _, err := r.Next(); err != readErr {
+			t.Errorf("contextResult.Next() error = %v", err)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{}, ctx: canceled}
+		if _, err := r.Next(); err != context.Canceled {
+			t.Errorf("contextResult.Next() error = %v", err)
+		}
+	})
+}
+
+func Test_contextResult_Boolean(t *testing.T) {
+	readErr := errors.New("read error")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("success", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{}, ctx: context.Background()}
+		if got, err := r.Boolean(); err != nil || !got {
+			t.Errorf("contextResult.Boolean() = %v, %v", got, err)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{err: readErr}, ctx: context.Background()}
+		if _, err := r.Boolean(); err != readErr {
+			t.Errorf("contextResult.Boolean() error = %v", err)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{}, ctx: canceled}
+		if _, err := r.Boolean(); err != context.Canceled {
+			t.Errorf("contextResult.Boolean() error = %v", err)
+		}
+	})
+}
+
+func Test_contextError(t *testing.T) {
+	readErr := errors.New("read error")
+	if err := contextError(context.Background(), readErr); err != readErr {
+		t.Errorf("contextError() = %v, want %v", err, readErr)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := contextError(ctx, readErr); err != context.Canceled {
+		t.Errorf("contextError() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_contextResult_Form(t *testing.T) {
+	t.Run("not a detector", func(t *testing.T) {
+		r := &contextResult{QueryResult: &mockQueryResult{}, ctx: context.Background()}
+		if got, err := r.Form(); err != nil || got != parser.FormUnknown {
+			t.Errorf("contextResult.Form() = %v, %v", got, err)
+		}
+	})
+	t.Run("detector", func(t *testing.T) {
+		x, err := DecodeJSONQueryResult(ioutil.NopCloser(strings.NewReader(`{"head": {}, "boolean": true}`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &contextResult{QueryResult: x, ctx: context.Background()}
+		if got, err := r.Form(); err != nil || got != parser.FormAsk {
+			t.Errorf("contextResult.Form() = %v, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultInitialBackoff is the backoff before the first retry used if it's not given.
+	DefaultInitialBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff is the max backoff used if it's not given.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy is the policy to retry requests failed with 429, 502, 503 and 504
+// or other `ErrRateLimited`, `ErrUnavailable` and `ErrTimeout` errors.
+// Other errors including network errors are not retried.
+type RetryPolicy struct {
+	// MaxAttempts is the max number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the backoff before the first retry. It's doubled for each retry
+	// and a random jitter of up to half of it is subtracted. It's capped at MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff. `Retry-After` of the response is used
+	// instead of the backoff. The request is not retried if `Retry-After`
+	// is longer than MaxBackoff.
+	MaxBackoff time.Duration
+	// RetryUpdates allows to retry SPARQL Update requests, which may not be idempotent.
+	RetryUpdates bool
+}
+
+// WithRetryPolicy sets the policy to retry queries and pings.
+// Updates are retried only if `RetryPolicy.RetryUpdates` is true.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("invalid max attempts %d", policy.MaxAttempts)
+		}
+		if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("negative backoff")
+		}
+		if policy.InitialBackoff == 0 {
+			policy.InitialBackoff = DefaultInitialBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = DefaultMaxBackoff
+		}
+		if policy.InitialBackoff > policy.MaxBackoff {
+			policy.InitialBackoff = policy.MaxBackoff
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// backoff returns the wait before the retry after the attempt.
+// It returns false if `Retry-After` is longer than MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, header http.Header) (time.Duration, bool) {
+	if d, ok := retryAfter(header.Get("Retry-After"), time.Now()); ok {
+		return d, d <= p.MaxBackoff
+	}
+	// Compare with the shifted max backoff so that the shift doesn't overflow.
+	d := p.MaxBackoff
+	if shift := uint(attempt - 1); shift < 63 && p.InitialBackoff < p.MaxBackoff>>shift {
+		d = p.InitialBackoff << shift
+	}
+	// nolint: gosec
+	return d - time.Duration(rand.Int63n(int64(d/2)+1)), true
+}
+
+// retryAfter parses `Retry-After` in seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// isRetryable reports whether the request may succeed by retrying it.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable) || errors.Is(err, ErrTimeout)
+}
+
+// send sends the request by the retry policy. update is true for SPARQL Update requests.
+// newError returns `*HTTPError` for an unsuccessful response and nil for a successful one.
+// The body of the unsuccessful response is closed. It doesn't wait for the retry if the
+// context deadline comes before it or `Retry-After` is longer than the max backoff.
+func (c *Client) send(
+	request *http.Request,
+	update bool,
+	newError func(resp *http.Response) error,
+) (*http.Response, error) {
+	ctx := request.Context()
+	maxAttempts := 1
+	if c.retryPolicy != nil && (!update || c.retryPolicy.RetryUpdates) {
+		maxAttempts = c.retryPolicy.MaxAttempts
+	}
+	for n := 1; ; n++ {
+		if n > 1 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.Clone(ctx)
+			request.Body = body
+		}
+		resp, err := c.HTTPClient.Do(request)
+		if err != nil {
+			return nil, timeoutError(err)
+		}
+		err = newError(resp)
+		if err == nil {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		var herr *HTTPError
+		if !errors.As(err, &herr) {
+			return nil, err
+		}
+		herr.Attempts = n
+		if n >= maxAttempts || !isRetryable(herr) {
+			return nil, err
+		}
+		wait, ok := c.retryPolicy.backoff(n, herr.Header)
+		if !ok {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, timeoutError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
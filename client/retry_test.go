@@ -0,0 +1,291 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// nolint: scopelint
+func TestWithRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		want    RetryPolicy
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			policy: RetryPolicy{MaxAttempts: 3},
+			want:   RetryPolicy{MaxAttempts: 3, InitialBackoff: DefaultInitialBackoff, MaxBackoff: DefaultMaxBackoff},
+		},
+		{
+			name:   "given",
+			policy: RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Minute, RetryUpdates: true},
+			want:   RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Second, MaxBackoff: time.Minute, RetryUpdates: true},
+		},
+		{name: "no attempts", policy: RetryPolicy{}, wantErr: true},
+		{
+			name:   "initial backoff over max",
+			policy: RetryPolicy{MaxAttempts: 40, InitialBackoff: 5 * time.Second, MaxBackoff: time.Millisecond},
+			want:   RetryPolicy{MaxAttempts: 40, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		},
+		{name: "negative backoff", policy: RetryPolicy{MaxAttempts: 2, InitialBackoff: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New("http://example.com/sparql", WithRetryPolicy(tt.policy))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithRetryPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *c.retryPolicy != tt.want {
+				t.Errorf("WithRetryPolicy() = %+v, want %+v", *c.retryPolicy, tt.want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: ""},
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: "-1"},
+		{value: "Wed, 21 Oct 2015 07:28:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Wed, 21 Oct 2015 07:27:00 GMT", want: 0, wantOK: true},
+		{value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := retryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for i := 0; i < 100; i++ {
+			if got, ok := p.backoff(attempt+1, http.Header{}); !ok || got < max/2 || got > max {
+				t.Fatalf("RetryPolicy.backoff(%d) = %v, want [%v, %v]", attempt+1, got, max/2, max)
+			}
+		}
+	}
+	if got, ok := p.backoff(100, http.Header{}); !ok || got < p.MaxBackoff/2 || got > p.MaxBackoff {
+		t.Errorf("RetryPolicy.backoff(100) = %v, %v", got, ok)
+	}
+	large := &RetryPolicy{MaxAttempts: 100, InitialBackoff: 5 * time.Second, MaxBackoff: time.Millisecond}
+	for attempt := 1; attempt <= large.MaxAttempts; attempt++ {
+		if got, ok := large.backoff(attempt, http.Header{}); !ok || got < large.MaxBackoff/2 || got > large.MaxBackoff {
+			t.Fatalf("RetryPolicy.backoff(%d) = %v, %v, want [%v, %v]", attempt, got, ok, large.MaxBackoff/2, large.MaxBackoff)
+		}
+	}
+	if got, ok := p.backoff(1, http.Header{"Retry-After": []string{"5"}}); !ok || got != 5*time.Second {
+		t.Errorf("RetryPolicy.backoff() with Retry-After = %v, %v, want %v", got, ok, 5*time.Second)
+	}
+	if got, ok := p.backoff(1, http.Header{"Retry-After": []string{"60"}}); ok {
+		t.Errorf("RetryPolicy.backoff() with Retry-After over MaxBackoff = %v, %v, want false", got, ok)
+	}
+}
+
+// flakyServer fails with the status code until the given number of requests.
+// It fails the requests whose bodies differ from the first one with 400.
+func flakyServer(t *testing.T, failures int32, statusCode int, retryAfter string) (*httptest.Server, *int32) {
+	var requests int32
+	var firstBody string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			n := atomic.AddInt32(&requests, 1)
+			if n == 1 {
+				firstBody = string(b)
+			} else if string(b) != firstBody {
+				http.Error(w, "body changed", http.StatusBadRequest)
+				return
+			}
+			if n <= failures {
+				if retryAfter != "" {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				http.Error(w, "try again", statusCode)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`<sparql><head/><boolean>true</boolean></sparql>`))
+		},
+	))
+	return server, &requests
+}
+
+func TestClient_retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	ctx := context.Background()
+
+	t.Run("query succeeds", func(t *testing.T) {
+		server, requests := flakyServer(t, 2, http.StatusServiceUnavailable, "")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy),
+			WithQueryMethod(QueryPostForm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.Query(ctx, "ASK {}")
+		if err != nil {
+			t.Fatalf("Client.Query() error = %v", err)
+		}
+		_ = result.Close()
+		if n := atomic.LoadInt32(requests); n != 3 {
+			t.Errorf("%d requests are sent, want 3", n)
+		}
+	})
+	t.Run("query gives up", func(t *testing.T) {
+		server, requests := flakyServer(t, 5, http.StatusTooManyRequests, "0")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Query(ctx, "ASK {}")
+		var herr *HTTPError
+		if !errors.As(err, &herr) || herr.Attempts != 3 {
+			t.Fatalf("Client.Query() error = %#v, want 3 attempts", err)
+		}
+		if want := "SPARQL query error after 3 attempts. status code: 429 msg: try again"; err.Error() != want {
+			t.Errorf("Client.Query() error = %v, want %v", err, want)
+		}
+		if n := atomic.LoadInt32(requests); n != 3 {
+			t.Errorf("%d requests are sent, want 3", n)
+		}
+	})
+	t.Run("not retryable", func(t *testing.T) {
+		server, requests := flakyServer(t, 1, http.StatusBadRequest, "")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Query(ctx, "ASK {}"); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Client.Query() error = %v", err)
+		}
+		if n := atomic.LoadInt32(requests); n != 1 {
+			t.Errorf("%d requests are sent, want 1", n)
+		}
+	})
+	t.Run("ping", func(t *testing.T) {
+		server, requests := flakyServer(t, 1, http.StatusBadGateway, "")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Ping(ctx); err != nil {
+			t.Errorf("Client.Ping() error = %v", err)
+		}
+		if n := atomic.LoadInt32(requests); n != 2 {
+			t.Errorf("%d requests are sent, want 2", n)
+		}
+	})
+	t.Run("update", func(t *testing.T) {
+		server, requests := flakyServer(t, 1, http.StatusServiceUnavailable, "")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Update(ctx, "CLEAR DEFAULT"); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Client.Update() error = %v", err)
+		}
+		if n := atomic.LoadInt32(requests); n != 1 {
+			t.Errorf("%d requests are sent, want 1", n)
+		}
+	})
+	t.Run("update allowed", func(t *testing.T) {
+		server, requests := flakyServer(t, 1, http.StatusServiceUnavailable, "")
+		defer server.Close()
+		allowed := policy
+		allowed.RetryUpdates = true
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(allowed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Update(ctx, "CLEAR DEFAULT"); err != nil {
+			t.Errorf("Client.Update() error = %v", err)
+		}
+		if n := atomic.LoadInt32(requests); n != 2 {
+			t.Errorf("%d requests are sent, want 2", n)
+		}
+	})
+	long := policy
+	long.MaxBackoff = time.Hour
+	t.Run("Retry-After over max backoff", func(t *testing.T) {
+		server, requests := flakyServer(t, 5, http.StatusServiceUnavailable, "86400")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		_, err = c.Query(ctx, "ASK {}")
+		var herr *HTTPError
+		if !errors.As(err, &herr) || herr.Attempts != 1 {
+			t.Errorf("Client.Query() error = %v, want 1 attempt", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Client.Query() waited %v beyond the max backoff", elapsed)
+		}
+		if n := atomic.LoadInt32(requests); n != 1 {
+			t.Errorf("%d requests are sent, want 1", n)
+		}
+	})
+	t.Run("deadline", func(t *testing.T) {
+		server, requests := flakyServer(t, 5, http.StatusServiceUnavailable, "60")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(long))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		start := time.Now()
+		_, err = c.Query(ctx, "ASK {}")
+		var herr *HTTPError
+		if !errors.As(err, &herr) || herr.Attempts != 1 {
+			t.Errorf("Client.Query() error = %v, want 1 attempt", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Client.Query() waited %v beyond the deadline", elapsed)
+		}
+		if n := atomic.LoadInt32(requests); n != 1 {
+			t.Errorf("%d requests are sent, want 1", n)
+		}
+	})
+	t.Run("canceled while waiting", func(t *testing.T) {
+		server, _ := flakyServer(t, 5, http.StatusServiceUnavailable, "60")
+		defer server.Close()
+		c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetryPolicy(long))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		if _, err := c.Query(ctx, "ASK {}"); err != context.Canceled {
+			t.Errorf("Client.Query() error = %v, want %v", err, context.Canceled)
+		}
+	})
+}
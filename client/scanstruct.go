@@ -0,0 +1,220 @@
+package client
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// termTypes are scanned from the RDF terms as they are.
+var termTypes = map[reflect.Type]bool{
+	reflect.TypeOf(URI("")):              true,
+	reflect.TypeOf(BNode("")):            true,
+	reflect.TypeOf(Literal{}):            true,
+	reflect.TypeOf(Term{}):               true,
+	reflect.TypeOf(NullTerm{}):           true,
+	reflect.TypeOf((*Value)(nil)).Elem(): true,
+}
+
+// ScanStruct reads the next bindings of the result into the struct pointed by dst.
+// It returns `io.EOF` after the last bindings. See `DecodeBindings` for the mapping.
+func ScanStruct(result QueryResult, dst interface{}) error {
+	bindings, err := result.Next()
+	if err != nil {
+		return err
+	}
+	return DecodeBindings(bindings, dst)
+}
+
+// DecodeBindings stores the bindings into the struct pointed by dst.
+//
+// A field is bound to the variable named by its `sparql` tag or by the field name.
+// The tag "-" skips the field. The tag option `lang` selects the literals by
+// the language tag, for example `sparql:"label,lang=en"` takes "foo"@en and "foo"@en-US.
+// Fields of embedded structs without tags are mapped as if they were in the outer struct.
+//
+//   - URI, BNode, Literal, Term, NullTerm and Value fields take the RDF terms as they are.
+//   - Other `sql.Scanner` fields scan the values converted by `Literal.Decode`.
+//   - Other fields take the converted values. Numbers are converted between kinds
+//     if they don't overflow and string fields take the lexical forms.
+//
+// Pointer fields are nil if the variables are unbound. Other fields become zero values.
+func DecodeBindings(bindings map[string]Value, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	return decodeStruct(bindings, v.Elem())
+}
+
+func decodeStruct(bindings map[string]Value, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("sparql")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			if err := decodeStruct(bindings, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		st := parseStructTag(tag, f.Name)
+		if err := decodeField(v.Field(i), bindings[st.name], st.lang); err != nil {
+			return fmt.Errorf("field %s: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// structTag is a parsed `sparql` struct tag.
+type structTag struct {
+	// name is the variable name.
+	name string
+	// lang is the language range to select literals.
+	lang string
+	// key reports whether the variable identifies a group. See `ScanGrouped`.
+	key bool
+}
+
+// parseStructTag parses the tag. The name is the field name if the tag has no name.
+func parseStructTag(tag, fieldName string) structTag {
+	options := strings.Split(tag, ",")
+	st := structTag{name: options[0]}
+	if st.name == "" {
+		st.name = fieldName
+	}
+	for _, o := range options[1:] {
+		switch {
+		case strings.HasPrefix(o, "lang="):
+			st.lang = o[len("lang="):]
+		case o == "key":
+			st.key = true
+		}
+	}
+	return st
+}
+
+// langMatches reports whether the language tag matches the language range
+// by the basic filtering of RFC 4647.
+func langMatches(tag, lang string) bool {
+	if lang == "*" {
+		return tag != ""
+	}
+	return strings.EqualFold(tag, lang) ||
+		len(tag) > len(lang) && tag[len(lang)] == '-' && strings.EqualFold(tag[:len(lang)], lang)
+}
+
+// selectLang returns nil if the term is a literal whose language tag doesn't match.
+func selectLang(term Value, lang string) Value {
+	if l, ok := term.(Literal); ok && lang != "" && !langMatches(l.LanguageTag, lang) {
+		return nil
+	}
+	return term
+}
+
+func decodeField(field reflect.Value, term Value, lang string) error {
+	if term = selectLang(term, lang); term == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := assign(elem.Elem(), term); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	return assign(field, term)
+}
+
+// assign stores the RDF term into the field.
+// nolint: gocyclo
+func assign(field reflect.Value, term Value) error {
+	if termTypes[field.Type()] && field.Kind() == reflect.Interface {
+		field.Set(reflect.ValueOf(&term).Elem())
+		return nil
+	}
+
+	native := interface{}(term)
+	if literal, ok := term.(Literal); ok {
+		if decoded, err := literal.Decode(); err == nil {
+			native = decoded
+		}
+	}
+
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		if termTypes[field.Type()] {
+			return scanner.Scan(term)
+		}
+		return scanner.Scan(native)
+	}
+
+	nv := reflect.ValueOf(native)
+	if nv.Type().AssignableTo(field.Type()) {
+		field.Set(nv)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(lexicalForm(term))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch nv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n := nv.Int(); !field.OverflowInt(n) {
+				field.SetInt(n)
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n := nv.Uint(); n <= 1<<63-1 && !field.OverflowInt(int64(n)) {
+				field.SetInt(int64(n))
+				return nil
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch nv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n := nv.Int(); n >= 0 && !field.OverflowUint(uint64(n)) {
+				field.SetUint(uint64(n))
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n := nv.Uint(); !field.OverflowUint(n) {
+				field.SetUint(n)
+				return nil
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		switch nv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(nv.Float())
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetFloat(float64(nv.Int()))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot assign %#v to %s", term, field.Type())
+}
+
+// lexicalForm returns the IRI, the blank node label or the lexical form of the literal.
+func lexicalForm(term Value) string {
+	switch v := term.(type) {
+	case URI:
+		return string(v)
+	case BNode:
+		return string(v)
+	case Literal:
+		return v.Value
+	default:
+		return fmt.Sprint(v)
+	}
+}
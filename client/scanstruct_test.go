@@ -0,0 +1,166 @@
+package client
+
+import (
+	"database/sql"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type scanBase struct {
+	ID URI `sparql:"s"`
+}
+
+type scanTarget struct {
+	scanBase
+	Name       string    `sparql:"name"`
+	Label      *string   `sparql:"label,lang=en"`
+	Age        int       `sparql:"age"`
+	Score      float32   `sparql:"score"`
+	Born       time.Time `sparql:"born"`
+	Homepage   *URI      `sparql:"homepage"`
+	Node       Value     `sparql:"node"`
+	Nick       NullTerm  `sparql:"nick"`
+	Count      sql.NullInt64
+	Ignored    string  `sparql:"-"`
+	Raw        Literal `sparql:"age"`
+	Mail       *Term   `sparql:"mail"`
+	unexported string
+}
+
+func TestDecodeBindings(t *testing.T) {
+	t.Run("not a struct pointer", func(t *testing.T) {
+		var s scanTarget
+		if err := DecodeBindings(nil, s); err == nil {
+			t.Errorf("DecodeBindings() error = %v", err)
+		}
+		var n *scanTarget
+		if err := DecodeBindings(nil, n); err == nil {
+			t.Errorf("DecodeBindings() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		got := scanTarget{Ignored: "keep", Nick: NullTerm{Term: URI("x"), Valid: true}}
+		err := DecodeBindings(map[string]Value{
+			"s":        URI("http://example.com/s"),
+			"name":     URI("http://example.com/name"),
+			"label":    Literal{Value: "foo", LanguageTag: "en-US"},
+			"age":      Literal{Value: "30", DataType: URI(xsdInteger)},
+			"score":    Literal{Value: "1.5", DataType: URI(xsdDecimal)},
+			"born":     Literal{Value: "2000-01-02", DataType: URI(xsdNamespace + "date")},
+			"homepage": URI("http://example.com/"),
+			"node":     BNode("b0"),
+			"Count":    Literal{Value: "3", DataType: URI(xsdNamespace + "int")},
+			"Ignored":  Literal{Value: "bar"},
+			"mail":     URI("mailto:foo@example.com"),
+		}, &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		label := "foo"
+		homepage := URI("http://example.com/")
+		want := scanTarget{
+			scanBase: scanBase{ID: URI("http://example.com/s")},
+			Name:     "http://example.com/name",
+			Label:    &label,
+			Age:      30,
+			Score:    1.5,
+			Born:     time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Homepage: &homepage,
+			Node:     BNode("b0"),
+			Count:    sql.NullInt64{Int64: 3, Valid: true},
+			Ignored:  "keep",
+			Raw:      Literal{Value: "30", DataType: URI(xsdInteger)},
+			Mail:     &Term{Term: URI("mailto:foo@example.com")},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DecodeBindings() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("language mismatch", func(t *testing.T) {
+		got := scanTarget{Label: new(string)}
+		err := DecodeBindings(map[string]Value{
+			"label": Literal{Value: "foo", LanguageTag: "ja"},
+		}, &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Label != nil {
+			t.Errorf("DecodeBindings() Label = %v, want nil", *got.Label)
+		}
+	})
+	t.Run("overflow", func(t *testing.T) {
+		var got struct {
+			N int8 `sparql:"n"`
+		}
+		err := DecodeBindings(map[string]Value{
+			"n": Literal{Value: "300", DataType: URI(xsdInteger)},
+		}, &got)
+		if err == nil {
+			t.Errorf("DecodeBindings() error = %v", err)
+		}
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		var got struct {
+			B bool `sparql:"b"`
+		}
+		err := DecodeBindings(map[string]Value{
+			"b": URI("http://example.com"),
+		}, &got)
+		if err == nil {
+			t.Errorf("DecodeBindings() error = %v", err)
+		}
+	})
+}
+
+func TestScanStruct(t *testing.T) {
+	result, err := DecodeTSVQueryResult(ioutil.NopCloser(strings.NewReader(
+		"?name\t?age\n\"Alice\"\t30\n\"Bob\"\t\n",
+	)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type person struct {
+		Name string `sparql:"name"`
+		Age  *int   `sparql:"age"`
+	}
+	var got []person
+	for {
+		var p person
+		err := ScanStruct(result, &p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p)
+	}
+	age := 30
+	want := []person{{Name: "Alice", Age: &age}, {Name: "Bob"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanStruct() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLangMatches(t *testing.T) {
+	tests := []struct {
+		tag, lang string
+		want      bool
+	}{
+		{tag: "en", lang: "en", want: true},
+		{tag: "EN-us", lang: "en", want: true},
+		{tag: "eng", lang: "en", want: false},
+		{tag: "", lang: "en", want: false},
+		{tag: "ja", lang: "*", want: true},
+		{tag: "", lang: "*", want: false},
+	}
+	for _, tt := range tests {
+		if got := langMatches(tt.tag, tt.lang); got != tt.want {
+			t.Errorf("langMatches(%q, %q) = %v, want %v", tt.tag, tt.lang, got, tt.want)
+		}
+	}
+}
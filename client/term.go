@@ -0,0 +1,189 @@
+package client
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Term is an RDF term for `sql.Rows.Scan`. Term.Term is one of URI, Literal or BNode.
+// Use `NullTerm` for unbound variables.
+type Term struct {
+	Term Value
+}
+
+// Scan implements `sql.Scanner`. Native values converted from literals are
+// converted back into typed literals. See `Literal.Scan`.
+func (t *Term) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return fmt.Errorf("cannot scan NULL into %T", t)
+	case URI, BNode, Literal:
+		t.Term = v
+		return nil
+	}
+	literal, err := literalOf(src)
+	if err != nil {
+		return err
+	}
+	t.Term = literal
+	return nil
+}
+
+// Value implements `driver.Valuer`. It returns the IRI, the blank node label or
+// the lexical form of the literal.
+func (t Term) Value() (driver.Value, error) {
+	switch v := t.Term.(type) {
+	case URI:
+		return v.Value()
+	case BNode:
+		return v.Value()
+	case Literal:
+		return v.Value, nil
+	default:
+		return nil, fmt.Errorf("unknown RDF term %T", v)
+	}
+}
+
+// NullTerm is an RDF term which may be unbound. It's similar to `sql.NullString`.
+type NullTerm struct {
+	Term  Value
+	Valid bool
+}
+
+// Scan implements `sql.Scanner`. NULL, an unbound variable, makes Valid false.
+func (n *NullTerm) Scan(src interface{}) error {
+	if src == nil {
+		n.Term, n.Valid = nil, false
+		return nil
+	}
+	var t Term
+	if err := t.Scan(src); err != nil {
+		return err
+	}
+	n.Term, n.Valid = t.Term, true
+	return nil
+}
+
+// Value implements `driver.Valuer`.
+func (n NullTerm) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return Term{Term: n.Term}.Value()
+}
+
+// Scan implements `sql.Scanner`.
+func (i *URI) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case URI:
+		*i = v
+	case string:
+		*i = URI(v)
+	case []byte:
+		*i = URI(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, i)
+	}
+	return nil
+}
+
+// Value implements `driver.Valuer`.
+func (i URI) Value() (driver.Value, error) {
+	return string(i), nil
+}
+
+// Scan implements `sql.Scanner`.
+func (b *BNode) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case BNode:
+		*b = v
+	case string:
+		*b = BNode(v)
+	case []byte:
+		*b = BNode(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, b)
+	}
+	return nil
+}
+
+// Value implements `driver.Valuer`.
+func (b BNode) Value() (driver.Value, error) {
+	return string(b), nil
+}
+
+// Scan implements `sql.Scanner`.
+//
+// Native values converted by `Literal.Decode` are converted back into typed literals.
+// The conversion is lossy. For example, an xsd:decimal becomes an xsd:double and
+// an xsd:date becomes an xsd:dateTime. Literal has no `driver.Valuer` implementation
+// because of the Value field. Use `Term` to pass literals to other drivers.
+func (l *Literal) Scan(src interface{}) error {
+	if v, ok := src.(Literal); ok {
+		*l = v
+		return nil
+	}
+	literal, err := literalOf(src)
+	if err != nil {
+		return err
+	}
+	*l = literal
+	return nil
+}
+
+// literalOf converts a native value into a typed literal.
+// Values handled by the registered encoders use them.
+// nolint: gocyclo
+func literalOf(src interface{}) (Literal, error) {
+	if literal, ok := EncodeLiteral(src); ok {
+		return literal, nil
+	}
+	switch v := src.(type) {
+	case string:
+		return Literal{Value: v}, nil
+	case int64:
+		return Literal{Value: strconv.FormatInt(v, 10), DataType: URI(xsdInteger)}, nil
+	case uint64:
+		return Literal{Value: strconv.FormatUint(v, 10), DataType: URI(xsdInteger)}, nil
+	case float64:
+		return Literal{Value: formatDouble(v), DataType: URI(xsdDouble)}, nil
+	case bool:
+		return Literal{Value: strconv.FormatBool(v), DataType: URI(xsdBoolean)}, nil
+	case time.Time:
+		return Literal{Value: v.Format(time.RFC3339Nano), DataType: URI(xsdNamespace + "dateTime")}, nil
+	case time.Duration:
+		return Literal{Value: formatDuration(v), DataType: URI(xsdNamespace + "dayTimeDuration")}, nil
+	case []byte:
+		return Literal{
+			Value:    base64.StdEncoding.EncodeToString(v),
+			DataType: URI(xsdNamespace + "base64Binary"),
+		}, nil
+	default:
+		return Literal{}, fmt.Errorf("cannot scan %T into client.Literal", src)
+	}
+}
+
+func formatDouble(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'E', -1, 64)
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return sign + "PT" + strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+}
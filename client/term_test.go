@@ -0,0 +1,146 @@
+package client
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nolint: scopelint
+func TestTerm_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Value
+		wantErr bool
+	}{
+		{name: "nil", src: nil, wantErr: true},
+		{name: "uri", src: URI("http://example.com"), want: URI("http://example.com")},
+		{name: "bnode", src: BNode("b0"), want: BNode("b0")},
+		{name: "literal", src: Literal{Value: "foo", LanguageTag: "en"}, want: Literal{Value: "foo", LanguageTag: "en"}},
+		{name: "string", src: "foo", want: Literal{Value: "foo"}},
+		{name: "int64", src: int64(-1), want: Literal{Value: "-1", DataType: URI(xsdInteger)}},
+		{name: "uint64", src: uint64(math.MaxUint64), want: Literal{Value: "18446744073709551615", DataType: URI(xsdInteger)}},
+		{name: "float64", src: 1.5, want: Literal{Value: "1.5E+00", DataType: URI(xsdDouble)}},
+		{name: "INF", src: math.Inf(-1), want: Literal{Value: "-INF", DataType: URI(xsdDouble)}},
+		{name: "bool", src: true, want: Literal{Value: "true", DataType: URI(xsdBoolean)}},
+		{
+			name: "time",
+			src:  time.Date(2015, time.November, 19, 0, 10, 11, 0, time.UTC),
+			want: Literal{Value: "2015-11-19T00:10:11Z", DataType: URI(xsdNamespace + "dateTime")},
+		},
+		{
+			name: "duration",
+			src:  -90 * time.Second,
+			want: Literal{Value: "-PT90S", DataType: URI(xsdNamespace + "dayTimeDuration")},
+		},
+		{name: "bytes", src: []byte("foo"), want: Literal{Value: "Zm9v", DataType: URI(xsdNamespace + "base64Binary")}},
+		{name: "unknown", src: struct{}{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Term
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Term.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.Term, tt.want) {
+				t.Errorf("Term.Scan() = %#v, want %#v", got.Term, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerm_round_trip(t *testing.T) {
+	for _, literal := range []Literal{
+		{Value: "-42", DataType: URI(xsdInteger)},
+		{Value: "true", DataType: URI(xsdBoolean)},
+		{Value: "PT1M30S", DataType: URI(xsdNamespace + "dayTimeDuration")},
+		{Value: "Zm9v", DataType: URI(xsdNamespace + "base64Binary")},
+	} {
+		v, err := literal.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Literal
+		if err := got.Scan(v); err != nil {
+			t.Errorf("Literal.Scan() error = %v", err)
+			continue
+		}
+		if decoded, err := got.Decode(); err != nil || !reflect.DeepEqual(decoded, v) {
+			t.Errorf("Literal.Scan() = %v, want %v", got, literal)
+		}
+	}
+}
+
+func TestTerm_Value(t *testing.T) {
+	tests := []struct {
+		term Value
+		want interface{}
+	}{
+		{term: URI("http://example.com"), want: "http://example.com"},
+		{term: BNode("b0"), want: "b0"},
+		{term: Literal{Value: "1", DataType: URI(xsdInteger)}, want: "1"},
+	}
+	for _, tt := range tests {
+		got, err := Term{Term: tt.term}.Value()
+		if err != nil {
+			t.Errorf("Term.Value() error = %v", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Term.Value() = %v, want %v", got, tt.want)
+		}
+	}
+	if _, err := (Term{}).Value(); err == nil {
+		t.Errorf("Term.Value() error = %v", err)
+	}
+}
+
+func TestNullTerm(t *testing.T) {
+	n := NullTerm{Term: URI("foo"), Valid: true}
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if n.Valid || n.Term != nil {
+		t.Errorf("NullTerm.Scan() = %+v", n)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("NullTerm.Value() = %v, %v", v, err)
+	}
+
+	if err := n.Scan(URI("http://example.com")); err != nil {
+		t.Fatal(err)
+	}
+	if want := (NullTerm{Term: URI("http://example.com"), Valid: true}); n != want {
+		t.Errorf("NullTerm.Scan() = %+v, want %+v", n, want)
+	}
+	if v, err := n.Value(); v != "http://example.com" || err != nil {
+		t.Errorf("NullTerm.Value() = %v, %v", v, err)
+	}
+}
+
+func TestURI_Scan(t *testing.T) {
+	var u URI
+	for _, src := range []interface{}{URI("a"), "a", []byte("a")} {
+		if err := u.Scan(src); err != nil || u != "a" {
+			t.Errorf("URI.Scan(%#v) = %v, %v", src, u, err)
+		}
+	}
+	if err := u.Scan(Literal{Value: "a"}); err == nil {
+		t.Errorf("URI.Scan() error = %v", err)
+	}
+}
+
+func TestBNode_Scan(t *testing.T) {
+	var b BNode
+	for _, src := range []interface{}{BNode("a"), "a", []byte("a")} {
+		if err := b.Scan(src); err != nil || b != "a" {
+			t.Errorf("BNode.Scan(%#v) = %v, %v", src, b, err)
+		}
+	}
+	if err := b.Scan(nil); err == nil {
+		t.Errorf("BNode.Scan() error = %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"io"
+)
+
+// TriGParser is the parser for TriG-formatted RDF datasets.
+type TriGParser struct {
+}
+
+// NewTriGParser returns `TriGParser` as the implementation of `QuadParser`.
+func NewTriGParser() QuadParser {
+	return &TriGParser{}
+}
+
+// Parse parses the TriG stream.
+func (*TriGParser) Parse(r io.ReadCloser) (QuadResult, error) {
+	return DecodeTriG(r)
+}
+
+// Format returns a media type. It's used as an `Accept` request header value.
+func (*TriGParser) Format() string {
+	return "application/trig"
+}
+
+// TriGQuadResult is the implementation to decode RDF 1.1 TriG.
+// https://www.w3.org/TR/trig/
+//
+// Quads are decoded statement by statement, even inside graph blocks.
+type TriGQuadResult struct {
+	r      io.ReadCloser
+	parser *turtleParser
+}
+
+// DecodeTriG decodes responded TriG document.
+func DecodeTriG(r io.ReadCloser) (QuadResult, error) {
+	parser := newTurtleParser(r)
+	parser.trig = true
+	return &TriGQuadResult{
+		r:      r,
+		parser: parser,
+	}, nil
+}
+
+// Next returns the next quad. It returns `io.EOF` after the last quad.
+func (t *TriGQuadResult) Next() (Quad, error) {
+	return t.parser.next()
+}
+
+// Close closes the underlying reader.
+func (t *TriGQuadResult) Close() error {
+	return t.r.Close()
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeQuads(t *testing.T, result QuadResult) ([]Quad, error) {
+	t.Helper()
+	defer result.Close()
+	var quads []Quad
+	for {
+		quad, err := result.Next()
+		if err == io.EOF {
+			return quads, nil
+		}
+		if err != nil {
+			return quads, err
+		}
+		quads = append(quads, quad)
+	}
+}
+
+func TestTriGParser_Format(t *testing.T) {
+	if got, want := NewTriGParser().Format(), "application/trig"; got != want {
+		t.Errorf("TriGParser.Format() = %v, want %v", got, want)
+	}
+}
+
+// nolint: scopelint
+func TestDecodeTriG(t *testing.T) {
+	ex := func(local string) URI {
+		return URI("http://example.com/" + local)
+	}
+	quad := func(s, p, o, g Value) Quad {
+		return Quad{Triple: Triple{Subject: s, Predicate: p, Object: o}, Graph: g}
+	}
+	tests := []struct {
+		name    string
+		doc     string
+		want    []Quad
+		wantErr bool
+	}{
+		{
+			name: "default graph",
+			doc: `@prefix ex: <http://example.com/> .
+ex:s ex:p ex:o .
+{ ex:s ex:p ex:o2 }`,
+			want: []Quad{
+				quad(ex("s"), ex("p"), ex("o"), nil),
+				quad(ex("s"), ex("p"), ex("o2"), nil),
+			},
+		},
+		{
+			name: "named graphs",
+			doc: `PREFIX ex: <http://example.com/>
+ex:g1 { ex:s ex:p ex:o . ex:s ex:q [ ex:r 1 ] . }
+GRAPH <http://example.com/g2> {
+  ex:s ex:p ex:o ;
+    ex:q "x"
+}
+_:g3 { ( ex:a ) ex:p ex:o }
+ex:s ex:p ex:o .`,
+			want: []Quad{
+				quad(ex("s"), ex("p"), ex("o"), ex("g1")),
+				quad(BNode("genid1"), ex("r"), Literal{Value: "1", DataType: URI(xsdInteger)}, ex("g1")),
+				quad(ex("s"), ex("q"), BNode("genid1"), ex("g1")),
+				quad(ex("s"), ex("p"), ex("o"), ex("g2")),
+				quad(ex("s"), ex("q"), Literal{Value: "x"}, ex("g2")),
+				quad(BNode("genid2"), URI(rdfFirst), ex("a"), BNode("g3")),
+				quad(BNode("genid2"), URI(rdfRest), URI(rdfNil), BNode("g3")),
+				quad(BNode("genid2"), ex("p"), ex("o"), BNode("g3")),
+				quad(ex("s"), ex("p"), ex("o"), nil),
+			},
+		},
+		{
+			name:    "unclosed graph",
+			doc:     `<http://example.com/g> { <http://example.com/s> <http://example.com/p> <http://example.com/o> .`,
+			want:    []Quad{quad(ex("s"), ex("p"), ex("o"), ex("g"))},
+			wantErr: true,
+		},
+		{
+			name:    "nested graph",
+			doc:     `{ { } }`,
+			wantErr: true,
+		},
+		{
+			name:    "directive in graph",
+			doc:     `{ @prefix ex: <http://example.com/> . }`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DecodeTriG(ioutil.NopCloser(strings.NewReader(tt.doc)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeQuads(t, result)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeTriG() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeTriG() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriGQuadResult_Close(t *testing.T) {
+	result, err := DecodeTriG(ioutil.NopCloser(strings.NewReader("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Close(); err != nil {
+		t.Errorf("TriGQuadResult.Close() error = %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/garsue/sparql/parser"
+)
+
+const (
+	xsdInteger = "http://www.w3.org/2001/XMLSchema#integer"
+	xsdDecimal = "http://www.w3.org/2001/XMLSchema#decimal"
+	xsdDouble  = "http://www.w3.org/2001/XMLSchema#double"
+	xsdBoolean = "http://www.w3.org/2001/XMLSchema#boolean"
+)
+
+// TSVResultParser is the parser for TSV-formatted query results.
+type TSVResultParser struct {
+}
+
+// NewTSVResultParser returns `TSVResultParser` as the implementation of `ResultParser`.
+func NewTSVResultParser() ResultParser {
+	return &TSVResultParser{}
+}
+
+// Parse parses the TSV query result stream.
+func (*TSVResultParser) Parse(r io.ReadCloser) (QueryResult, error) {
+	return DecodeTSVQueryResult(r)
+}
+
+// Format returns a format name string. It's used as a `format` request header value.
+func (*TSVResultParser) Format() string {
+	return "text/tab-separated-values"
+}
+
+// TSVQueryResult is the implementation to decode SPARQL 1.1 Query Results TSV Format.
+// https://www.w3.org/TR/sparql11-results-csv-tsv/
+//
+// Each field is decoded from the SPARQL/Turtle term syntax into `URI`, `Literal`
+// or `BNode`. An empty field is an unbound variable.
+type TSVQueryResult struct {
+	r         io.ReadCloser
+	variables []string
+	reader    *bufio.Reader
+}
+
+// DecodeTSVQueryResult decodes responded TSV Query Result.
+func DecodeTSVQueryResult(r io.ReadCloser) (QueryResult, error) {
+	reader := bufio.NewReader(r)
+	line, err := readTSVLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(line, "\t")
+	variables := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f == "" {
+			continue
+		}
+		variables = append(variables, strings.TrimLeft(f, "?$"))
+	}
+	return &TSVQueryResult{
+		r:         r,
+		variables: variables,
+		reader:    reader,
+	}, nil
+}
+
+// readTSVLine reads a line without the line terminator.
+// It returns `io.EOF` only when no more data is available.
+func readTSVLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Variables returns query variables.
+func (t *TSVQueryResult) Variables() []string {
+	return t.variables
+}
+
+// Next returns the next bindings. It returns `io.EOF` after the last bindings.
+func (t *TSVQueryResult) Next() (map[string]Value, error) {
+	line, err := readTSVLine(t.reader)
+	if err != nil {
+		return nil, err
+	}
+	bindings := make(map[string]Value, len(t.variables))
+	for i, field := range strings.Split(line, "\t") {
+		if i >= len(t.variables) || field == "" {
+			continue
+		}
+		value, err := decodeTSVTerm(field)
+		if err != nil {
+			return nil, err
+		}
+		bindings[t.variables[i]] = value
+	}
+	return bindings, nil
+}
+
+// Form always returns `parser.FormSelect`. The TSV format has no representation for ASK results.
+func (*TSVQueryResult) Form() (parser.Form, error) {
+	return parser.FormSelect, nil
+}
+
+// Boolean is not supported. The TSV format has no representation for ASK results.
+func (*TSVQueryResult) Boolean() (bool, error) {
+	return false, errNoBoolean
+}
+
+// Close closes the underlying reader.
+func (t *TSVQueryResult) Close() error {
+	return t.r.Close()
+}
+
+// decodeTSVTerm decodes an RDF term written in the SPARQL/Turtle syntax.
+func decodeTSVTerm(s string) (Value, error) {
+	switch {
+	case strings.HasPrefix(s, "<") && strings.HasSuffix(s, ">"):
+		return URI(s[1 : len(s)-1]), nil
+	case strings.HasPrefix(s, "_:"):
+		return BNode(s[2:]), nil
+	case strings.HasPrefix(s, `"`), strings.HasPrefix(s, "'"):
+		return decodeQuotedLiteral(s)
+	case s == "true", s == "false":
+		return Literal{Value: s, DataType: URI(xsdBoolean)}, nil
+	}
+	if dataType := numericDataType(s); dataType != "" {
+		return Literal{Value: s, DataType: URI(dataType)}, nil
+	}
+	return nil, fmt.Errorf("unknown RDF term %q", s)
+}
+
+// decodeQuotedLiteral decodes a quoted literal with an optional language tag or datatype.
+func decodeQuotedLiteral(s string) (Value, error) {
+	value, rest, err := unquote(s)
+	if err != nil {
+		return nil, err
+	}
+	literal := Literal{Value: value}
+	switch {
+	case rest == "":
+	case strings.HasPrefix(rest, "@") && len(rest) > 1:
+		literal.LanguageTag = rest[1:]
+	case strings.HasPrefix(rest, "^^<") && strings.HasSuffix(rest, ">"):
+		literal.DataType = URI(rest[3 : len(rest)-1])
+	case strings.HasPrefix(rest, "^^") && strings.Contains(rest, ":"):
+		literal.DataType = PrefixedName(rest[2:])
+	default:
+		return nil, fmt.Errorf("malformed literal %q", s)
+	}
+	return literal, nil
+}
+
+// unquote reads a quoted string at the head of s and unescapes it.
+// It returns the remaining text after the closing quote.
+func unquote(s string) (value, rest string, err error) {
+	quote := s[0]
+	delim := s[:1]
+	if len(s) >= 6 && s[1] == quote && s[2] == quote {
+		delim = s[:3]
+	}
+	var b strings.Builder
+	for i := len(delim); i < len(s); {
+		if strings.HasPrefix(s[i:], delim) {
+			return b.String(), s[i+len(delim):], nil
+		}
+		if s[i] != '\\' {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size
+			continue
+		}
+		r, size, err := unescape(s[i:])
+		if err != nil {
+			return "", "", err
+		}
+		b.WriteRune(r)
+		i += size
+	}
+	return "", "", fmt.Errorf("unterminated string %q", s)
+}
+
+// unescape decodes an ECHAR or UCHAR escape sequence at the head of s.
+func unescape(s string) (rune, int, error) {
+	if len(s) < 2 {
+		return 0, 0, fmt.Errorf("malformed escape sequence %q", s)
+	}
+	switch s[1] {
+	case 't':
+		return '\t', 2, nil
+	case 'b':
+		return '\b', 2, nil
+	case 'n':
+		return '\n', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case 'f':
+		return '\f', 2, nil
+	case '"', '\'', '\\':
+		return rune(s[1]), 2, nil
+	case 'u':
+		return unescapeHex(s, 4)
+	case 'U':
+		return unescapeHex(s, 8)
+	default:
+		return 0, 0, fmt.Errorf("malformed escape sequence %q", s[:2])
+	}
+}
+
+func unescapeHex(s string, digits int) (rune, int, error) {
+	if len(s) < 2+digits {
+		return 0, 0, fmt.Errorf("malformed escape sequence %q", s)
+	}
+	n, err := strconv.ParseUint(s[2:2+digits], 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rune(n), 2 + digits, nil
+}
+
+var (
+	integerPattern = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	decimalPattern = regexp.MustCompile(`^[+-]?[0-9]*\.[0-9]+$`)
+	doublePattern  = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)[eE][+-]?[0-9]+$`)
+)
+
+// numericDataType returns the datatype of an unquoted numeric literal,
+// or the empty string if s is not a number.
+func numericDataType(s string) string {
+	switch {
+	case integerPattern.MatchString(s):
+		return xsdInteger
+	case decimalPattern.MatchString(s):
+		return xsdDecimal
+	case doublePattern.MatchString(s):
+		return xsdDouble
+	default:
+		return ""
+	}
+}
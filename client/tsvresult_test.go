@@ -0,0 +1,145 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTSVResultParser_Format(t *testing.T) {
+	if got, want := NewTSVResultParser().Format(), "text/tab-separated-values"; got != want {
+		t.Errorf("TSVResultParser.Format() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeTSVQueryResult(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader(``))
+		if _, err := DecodeTSVQueryResult(reader); err != io.EOF {
+			t.Errorf("DecodeTSVQueryResult() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		reader := ioutil.NopCloser(strings.NewReader("?x\t?y\n"))
+		got, err := DecodeTSVQueryResult(reader)
+		if err != nil {
+			t.Errorf("DecodeTSVQueryResult() error = %v", err)
+			return
+		}
+		if got, want := got.Variables(), []string{"x", "y"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("DecodeTSVQueryResult() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestTSVQueryResult_Next(t *testing.T) {
+	t.Run("unknown term", func(t *testing.T) {
+		result, err := DecodeTSVQueryResult(ioutil.NopCloser(strings.NewReader(
+			"?x\nfoo\n",
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := result.Next(); err == nil {
+			t.Errorf("TSVQueryResult.Next() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		result, err := DecodeTSVQueryResult(ioutil.NopCloser(strings.NewReader(
+			"?x\t?hpage\t?name\t?age\n" +
+				"_:r2\t<http://work.example.org/bob/>\t\"Bob\"@en\t30\n" +
+				"\t\t\"Alice\\t\\\"A\\\"\"\r\n" +
+				"\t\t\"x\"^^<http://www.w3.org/2001/XMLSchema#string>\t\"1\"^^xsd:integer",
+		)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []map[string]Value{
+			{
+				"x":     BNode("r2"),
+				"hpage": URI("http://work.example.org/bob/"),
+				"name":  Literal{Value: "Bob", LanguageTag: "en"},
+				"age":   Literal{Value: "30", DataType: URI(xsdInteger)},
+			},
+			{
+				"name": Literal{Value: "Alice\t\"A\""},
+			},
+			{
+				"name": Literal{Value: "x", DataType: URI("http://www.w3.org/2001/XMLSchema#string")},
+				"age":  Literal{Value: "1", DataType: PrefixedName("xsd:integer")},
+			},
+		}
+		for _, w := range want {
+			got, err := result.Next()
+			if err != nil {
+				t.Errorf("TSVQueryResult.Next() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, w) {
+				t.Errorf("TSVQueryResult.Next() = %v, want %v", got, w)
+			}
+		}
+		if _, err := result.Next(); err != io.EOF {
+			t.Errorf("TSVQueryResult.Next() error = %v", err)
+		}
+	})
+}
+
+// nolint: scopelint
+func Test_decodeTSVTerm(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Value
+		wantErr bool
+	}{
+		{name: "uri", s: "<http://example.com/>", want: URI("http://example.com/")},
+		{name: "bnode", s: "_:b0", want: BNode("b0")},
+		{name: "plain", s: `"foo"`, want: Literal{Value: "foo"}},
+		{name: "single quote", s: `'foo'`, want: Literal{Value: "foo"}},
+		{name: "long quote", s: `"""fo"o"""`, want: Literal{Value: `fo"o`}},
+		{name: "empty", s: `""`, want: Literal{Value: ""}},
+		{name: "unicode escape", s: `"あ\U0001F600"`, want: Literal{Value: "あ😀"}},
+		{name: "lang", s: `"foo"@en-US`, want: Literal{Value: "foo", LanguageTag: "en-US"}},
+		{name: "integer", s: "-1", want: Literal{Value: "-1", DataType: URI(xsdInteger)}},
+		{name: "decimal", s: "1.5", want: Literal{Value: "1.5", DataType: URI(xsdDecimal)}},
+		{name: "double", s: "1.0e-3", want: Literal{Value: "1.0e-3", DataType: URI(xsdDouble)}},
+		{name: "boolean", s: "true", want: Literal{Value: "true", DataType: URI(xsdBoolean)}},
+		{name: "unterminated", s: `"foo`, wantErr: true},
+		{name: "bad escape", s: `"\x"`, wantErr: true},
+		{name: "bad unicode escape", s: `"\u30"`, wantErr: true},
+		{name: "bad suffix", s: `"foo"bar`, wantErr: true},
+		{name: "unknown", s: "1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTSVTerm(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeTSVTerm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeTSVTerm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSVQueryResult_Boolean(t *testing.T) {
+	var x TSVQueryResult
+	if _, err := x.Boolean(); err == nil {
+		t.Errorf("TSVQueryResult.Boolean() error = %v", err)
+	}
+}
+
+func TestTSVQueryResult_Close(t *testing.T) {
+	x := &TSVQueryResult{
+		r: ioutil.NopCloser(strings.NewReader("")),
+	}
+	if err := x.Close(); err != nil {
+		t.Errorf("TSVQueryResult.Close() error = %v", err)
+	}
+}
@@ -0,0 +1,951 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+const (
+	rdfNS    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	rdfType  = rdfNS + "type"
+	rdfFirst = rdfNS + "first"
+	rdfRest  = rdfNS + "rest"
+	rdfNil   = rdfNS + "nil"
+)
+
+// TurtleParser is the parser for Turtle-formatted RDF graphs.
+type TurtleParser struct {
+}
+
+// NewTurtleParser returns `TurtleParser` as the implementation of `GraphParser`.
+func NewTurtleParser() GraphParser {
+	return &TurtleParser{}
+}
+
+// Parse parses the Turtle stream.
+func (*TurtleParser) Parse(r io.ReadCloser) (GraphResult, error) {
+	return DecodeTurtle(r)
+}
+
+// Format returns a media type. It's used as an `Accept` request header value.
+func (*TurtleParser) Format() string {
+	return "text/turtle"
+}
+
+// TurtleGraphResult is the implementation to decode RDF 1.1 Turtle.
+// https://www.w3.org/TR/turtle/
+//
+// Triples are decoded statement by statement.
+type TurtleGraphResult struct {
+	r      io.ReadCloser
+	parser *turtleParser
+}
+
+// DecodeTurtle decodes responded Turtle document.
+func DecodeTurtle(r io.ReadCloser) (GraphResult, error) {
+	return &TurtleGraphResult{
+		r:      r,
+		parser: newTurtleParser(r),
+	}, nil
+}
+
+// Next returns the next triple. It returns `io.EOF` after the last triple.
+func (t *TurtleGraphResult) Next() (Triple, error) {
+	q, err := t.parser.next()
+	return q.Triple, err
+}
+
+// Close closes the underlying reader.
+func (t *TurtleGraphResult) Close() error {
+	return t.r.Close()
+}
+
+// turtleParser is a streaming parser of Turtle, TriG and their subsets,
+// N-Triples and N-Quads.
+type turtleParser struct {
+	lexer    *rdfLexer
+	base     *url.URL
+	prefixes map[string]string
+	quads    []Quad
+	bnodes   int
+	err      error
+	// trig accepts graph blocks.
+	trig bool
+	// nquads accepts only N-Quads statements.
+	nquads bool
+	// inBlock is true inside the graph block of TriG.
+	inBlock bool
+	// graph is the current graph. It's nil for the default graph.
+	graph Value
+}
+
+func newTurtleParser(r io.Reader) *turtleParser {
+	return &turtleParser{
+		lexer:    &rdfLexer{r: bufio.NewReader(r), line: 1},
+		prefixes: make(map[string]string),
+	}
+}
+
+// next returns the next quad. The quads of a statement are buffered.
+func (p *turtleParser) next() (Quad, error) {
+	for len(p.quads) == 0 {
+		if p.err != nil {
+			return Quad{}, p.err
+		}
+		p.err = p.statement()
+	}
+	q := p.quads[0]
+	p.quads = p.quads[1:]
+	return q, nil
+}
+
+func (p *turtleParser) emit(s, pred, o Value) {
+	p.quads = append(p.quads, Quad{
+		Triple: Triple{Subject: s, Predicate: pred, Object: o},
+		Graph:  p.graph,
+	})
+}
+
+// statement parses a directive, triples or a part of a graph block.
+// It returns `io.EOF` at the end of the document.
+func (p *turtleParser) statement() error {
+	r, err := p.lexer.peekToken()
+	if err == io.EOF && p.inBlock {
+		return p.lexer.errorf("unexpected EOF")
+	}
+	if err != nil {
+		return err
+	}
+	if err := p.statementBody(r); err != nil {
+		if err == io.EOF {
+			return p.lexer.errorf("unexpected EOF")
+		}
+		return err
+	}
+	return nil
+}
+
+// nolint: gocyclo
+func (p *turtleParser) statementBody(r rune) error {
+	switch {
+	case p.nquads:
+		return p.quadStatement()
+	case p.inBlock && r == '}':
+		_, _ = p.lexer.read()
+		p.inBlock = false
+		p.graph = nil
+		return nil
+	case p.trig && !p.inBlock && r == '{':
+		_, _ = p.lexer.read()
+		p.inBlock = true
+		return nil
+	case r == '@' && !p.inBlock:
+		return p.atDirective()
+	case !isNameStart(r):
+		return p.triples3()
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return err
+	}
+	switch {
+	case p.inBlock:
+	case strings.EqualFold(name, "PREFIX"):
+		return p.prefixDirective()
+	case strings.EqualFold(name, "BASE"):
+		return p.baseDirective()
+	case p.trig && strings.EqualFold(name, "GRAPH"):
+		return p.graphBlock()
+	}
+	subject, err := p.nameTerm(name)
+	if err != nil {
+		return err
+	}
+	return p.triples(subject, false)
+}
+
+// graphBlock parses the label and `{` after `GRAPH`.
+func (p *turtleParser) graphBlock() error {
+	label, err := p.term(false)
+	if err != nil {
+		return err
+	}
+	if err := p.lexer.expectToken('{'); err != nil {
+		return err
+	}
+	p.graph = label
+	p.inBlock = true
+	return nil
+}
+
+// quadStatement parses a statement of N-Quads.
+func (p *turtleParser) quadStatement() error {
+	subject, err := p.term(false)
+	if err != nil {
+		return err
+	}
+	predicate, err := p.verb()
+	if err != nil {
+		return err
+	}
+	object, err := p.term(true)
+	if err != nil {
+		return err
+	}
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return err
+	}
+	var graph Value
+	if r != '.' {
+		if graph, err = p.term(false); err != nil {
+			return err
+		}
+	}
+	if err := p.lexer.expectToken('.'); err != nil {
+		return err
+	}
+	p.quads = append(p.quads, Quad{
+		Triple: Triple{Subject: subject, Predicate: predicate, Object: object},
+		Graph:  graph,
+	})
+	return nil
+}
+
+// atDirective parses `@prefix` or `@base` directive.
+func (p *turtleParser) atDirective() error {
+	if _, err := p.lexer.read(); err != nil {
+		return err
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "prefix":
+		err = p.prefixDirective()
+	case "base":
+		err = p.baseDirective()
+	default:
+		return p.lexer.errorf("unknown directive @%s", name)
+	}
+	if err != nil {
+		return err
+	}
+	return p.lexer.expectToken('.')
+}
+
+func (p *turtleParser) prefixDirective() error {
+	if _, err := p.lexer.peekToken(); err != nil {
+		return err
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(name, ":") {
+		return p.lexer.errorf("malformed prefix %q", name)
+	}
+	iri, err := p.iriToken()
+	if err != nil {
+		return err
+	}
+	p.prefixes[strings.TrimSuffix(name, ":")] = string(iri)
+	return nil
+}
+
+func (p *turtleParser) baseDirective() error {
+	iri, err := p.iriToken()
+	if err != nil {
+		return err
+	}
+	base, err := url.Parse(string(iri))
+	if err != nil {
+		return p.lexer.errorf("malformed base %q", iri)
+	}
+	p.base = base
+	return nil
+}
+
+func (p *turtleParser) iriToken() (URI, error) {
+	if err := p.lexer.expectToken('<'); err != nil {
+		return "", err
+	}
+	return p.iri()
+}
+
+// triples3 parses triples starting with an IRI, a blank node or a collection.
+func (p *turtleParser) triples3() error {
+	r, err := p.lexer.peek()
+	if err != nil {
+		return err
+	}
+	subject, err := p.term(false)
+	if err != nil {
+		return err
+	}
+	return p.triples(subject, r == '[')
+}
+
+// triples parses the rest of triples after the subject.
+// In TriG, the subject followed by `{` is the graph label.
+func (p *turtleParser) triples(subject Value, propertyList bool) error {
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return err
+	}
+	switch {
+	case p.trig && !p.inBlock && r == '{':
+		_, _ = p.lexer.read()
+		p.graph = subject
+		p.inBlock = true
+		return nil
+	case propertyList && (r == '.' || r == '}'):
+		// A blank node property list can be a statement by itself.
+		return p.endTriples()
+	}
+	if err := p.predicateObjectList(subject); err != nil {
+		return err
+	}
+	return p.endTriples()
+}
+
+// endTriples reads the end of triples. `.` is optional before `}` in a graph block.
+func (p *turtleParser) endTriples() error {
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return err
+	}
+	if p.inBlock && r == '}' {
+		return nil
+	}
+	return p.lexer.expectToken('.')
+}
+
+func (p *turtleParser) predicateObjectList(subject Value) error {
+	for {
+		verb, err := p.verb()
+		if err != nil {
+			return err
+		}
+		if err := p.objectList(subject, verb); err != nil {
+			return err
+		}
+
+		r, err := p.lexer.peekToken()
+		if err != nil {
+			return err
+		}
+		if r != ';' {
+			return nil
+		}
+		for r == ';' {
+			if _, err := p.lexer.read(); err != nil {
+				return err
+			}
+			if r, err = p.lexer.peekToken(); err != nil {
+				return err
+			}
+		}
+		if r == '.' || r == ']' || r == '}' {
+			return nil
+		}
+	}
+}
+
+func (p *turtleParser) objectList(subject, verb Value) error {
+	for {
+		object, err := p.term(true)
+		if err != nil {
+			return err
+		}
+		p.emit(subject, verb, object)
+
+		r, err := p.lexer.peekToken()
+		if err != nil {
+			return err
+		}
+		if r != ',' {
+			return nil
+		}
+		if _, err := p.lexer.read(); err != nil {
+			return err
+		}
+	}
+}
+
+// verb parses a predicate or `a`.
+func (p *turtleParser) verb() (URI, error) {
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return "", err
+	}
+	if r == '<' {
+		return p.iriOrName()
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return "", err
+	}
+	if name == "a" {
+		return URI(rdfType), nil
+	}
+	return p.prefixedName(name)
+}
+
+// iriOrName parses an IRIREF or a prefixed name.
+func (p *turtleParser) iriOrName() (URI, error) {
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return "", err
+	}
+	if r == '<' {
+		_, _ = p.lexer.read()
+		return p.iri()
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return "", err
+	}
+	return p.prefixedName(name)
+}
+
+// term parses a subject or an object. Literals are accepted only if literal is true.
+// nolint: gocyclo
+func (p *turtleParser) term(literal bool) (Value, error) {
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case r == '<':
+		_, _ = p.lexer.read()
+		return p.iri()
+	case r == '[':
+		_, _ = p.lexer.read()
+		return p.blankNodePropertyList()
+	case r == '(':
+		_, _ = p.lexer.read()
+		return p.collection()
+	case !literal:
+	case r == '"' || r == '\'':
+		return p.literal()
+	case r == '+' || r == '-' || r == '.' || (r >= '0' && r <= '9'):
+		return p.numeric()
+	}
+	if !isNameStart(r) {
+		return nil, p.lexer.errorf("unexpected %q", r)
+	}
+	name, err := p.lexer.readName()
+	if err != nil {
+		return nil, err
+	}
+	if literal && (name == "true" || name == "false") {
+		return Literal{Value: name, DataType: URI(xsdBoolean)}, nil
+	}
+	return p.nameTerm(name)
+}
+
+// nameTerm returns the blank node or the IRI of the name which is not a keyword.
+func (p *turtleParser) nameTerm(name string) (Value, error) {
+	if strings.HasPrefix(name, "_:") {
+		return p.blankNodeLabel(name)
+	}
+	return p.prefixedName(name)
+}
+
+func (p *turtleParser) prefixedName(name string) (URI, error) {
+	i := strings.Index(name, ":")
+	if i < 0 {
+		return "", p.lexer.errorf("unexpected %q", name)
+	}
+	ns, ok := p.prefixes[name[:i]]
+	if !ok {
+		return "", p.lexer.errorf("undefined prefix %q", name[:i])
+	}
+	return URI(ns + unescapeLocal(name[i+1:])), nil
+}
+
+// unescapeLocal removes backslashes of PN_LOCAL_ESC.
+func unescapeLocal(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// iri reads an IRIREF after `<` and resolves it against the base IRI.
+func (p *turtleParser) iri() (URI, error) {
+	var b strings.Builder
+	for {
+		r, err := p.lexer.read()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '>':
+			return p.resolve(b.String()), nil
+		case '\\':
+			r, err = p.lexer.readEscape(false)
+			if err != nil {
+				return "", err
+			}
+		case ' ', '\t', '\n', '\r', '<', '"', '{', '}', '|', '^', '`':
+			return "", p.lexer.errorf("unexpected %q in IRI", r)
+		}
+		b.WriteRune(r)
+	}
+}
+
+func (p *turtleParser) resolve(iri string) URI {
+	if p.base == nil {
+		return URI(iri)
+	}
+	ref, err := url.Parse(iri)
+	if err != nil || ref.IsAbs() {
+		return URI(iri)
+	}
+	return URI(p.base.ResolveReference(ref).String())
+}
+
+func (p *turtleParser) blankNodeLabel(name string) (Value, error) {
+	if len(name) == 2 || strings.Contains(name[2:], ":") {
+		return nil, p.lexer.errorf("malformed blank node %q", name)
+	}
+	label := name[2:]
+	// Rename labels which may collide with the generated ones. See `newBNode`.
+	if strings.HasPrefix(label, genIDPrefix) {
+		label = genIDPrefix + "-" + label
+	}
+	return BNode(label), nil
+}
+
+// genIDPrefix is the prefix of the generated blank node labels.
+const genIDPrefix = "genid"
+
+// newBNode returns a fresh blank node for `[]` and collections. The label is
+// `genid` and digits, and labels in the document starting with `genid` become
+// `genid-genid...` not to be merged with it.
+func (p *turtleParser) newBNode() BNode {
+	p.bnodes++
+	return BNode(fmt.Sprintf("%s%d", genIDPrefix, p.bnodes))
+}
+
+// blankNodePropertyList parses `[ predicateObjectList ]` after `[`.
+func (p *turtleParser) blankNodePropertyList() (Value, error) {
+	bnode := p.newBNode()
+	r, err := p.lexer.peekToken()
+	if err != nil {
+		return nil, err
+	}
+	if r != ']' {
+		if err := p.predicateObjectList(bnode); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.lexer.expectToken(']'); err != nil {
+		return nil, err
+	}
+	return bnode, nil
+}
+
+// collection parses `( object* )` after `(`.
+func (p *turtleParser) collection() (Value, error) {
+	var head, last Value = URI(rdfNil), nil
+	for {
+		r, err := p.lexer.peekToken()
+		if err != nil {
+			return nil, err
+		}
+		if r == ')' {
+			_, _ = p.lexer.read()
+			if last != nil {
+				p.emit(last, URI(rdfRest), URI(rdfNil))
+			}
+			return head, nil
+		}
+
+		node := p.newBNode()
+		if last == nil {
+			head = node
+		} else {
+			p.emit(last, URI(rdfRest), node)
+		}
+		// Emit rdf:first before the triples of the nested object.
+		i := len(p.quads)
+		object, err := p.term(true)
+		if err != nil {
+			return nil, err
+		}
+		p.quads = append(p.quads, Quad{})
+		copy(p.quads[i+1:], p.quads[i:])
+		p.quads[i] = Quad{
+			Triple: Triple{Subject: node, Predicate: URI(rdfFirst), Object: object},
+			Graph:  p.graph,
+		}
+		last = node
+	}
+}
+
+func (p *turtleParser) literal() (Value, error) {
+	value, err := p.lexer.readString()
+	if err != nil {
+		return nil, err
+	}
+	literal := Literal{Value: value}
+	r, err := p.lexer.peek()
+	if err == io.EOF {
+		return literal, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch r {
+	case '@':
+		_, _ = p.lexer.read()
+		tag, err := p.lexer.readLanguageTag()
+		if err != nil {
+			return nil, err
+		}
+		literal.LanguageTag = tag
+	case '^':
+		_, _ = p.lexer.read()
+		if err := p.lexer.expect('^'); err != nil {
+			return nil, err
+		}
+		dataType, err := p.iriOrName()
+		if err != nil {
+			return nil, err
+		}
+		literal.DataType = dataType
+	}
+	return literal, nil
+}
+
+func (p *turtleParser) numeric() (Value, error) {
+	var b strings.Builder
+	for {
+		r, err := p.lexer.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.ContainsRune("0123456789+-.eE", r) {
+			p.lexer.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	// The last dot terminates the statement.
+	if strings.HasSuffix(s, ".") {
+		s = s[:len(s)-1]
+		p.lexer.unread('.')
+	}
+	dataType := numericDataType(s)
+	if dataType == "" {
+		return nil, p.lexer.errorf("malformed number %q", s)
+	}
+	return Literal{Value: s, DataType: URI(dataType)}, nil
+}
+
+// rdfLexer reads runes of Turtle family documents with pushback and line counting.
+type rdfLexer struct {
+	r    *bufio.Reader
+	back []rune
+	line int
+}
+
+func (l *rdfLexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+func (l *rdfLexer) read() (rune, error) {
+	var r rune
+	if n := len(l.back); n > 0 {
+		r = l.back[n-1]
+		l.back = l.back[:n-1]
+	} else {
+		var err error
+		if r, _, err = l.r.ReadRune(); err != nil {
+			return 0, err
+		}
+	}
+	if r == '\n' {
+		l.line++
+	}
+	return r, nil
+}
+
+func (l *rdfLexer) unread(r rune) {
+	if r == '\n' {
+		l.line--
+	}
+	l.back = append(l.back, r)
+}
+
+func (l *rdfLexer) peek() (rune, error) {
+	r, err := l.read()
+	if err != nil {
+		return 0, err
+	}
+	l.unread(r)
+	return r, nil
+}
+
+// peekToken skips white spaces and comments, and returns the next rune without consuming it.
+func (l *rdfLexer) peekToken() (rune, error) {
+	for {
+		r, err := l.read()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case unicode.IsSpace(r):
+		case r == '#':
+			if err := l.skipLine(); err != nil {
+				return 0, err
+			}
+		default:
+			l.unread(r)
+			return r, nil
+		}
+	}
+}
+
+func (l *rdfLexer) skipLine() error {
+	for {
+		r, err := l.read()
+		if err != nil {
+			return err
+		}
+		if r == '\n' {
+			return nil
+		}
+	}
+}
+
+func (l *rdfLexer) expect(want rune) error {
+	r, err := l.read()
+	if err != nil {
+		return err
+	}
+	if r != want {
+		return l.errorf("unexpected %q, want %q", r, want)
+	}
+	return nil
+}
+
+// expectToken skips white spaces and comments, and reads the rune.
+func (l *rdfLexer) expectToken(want rune) error {
+	if _, err := l.peekToken(); err != nil {
+		return err
+	}
+	return l.expect(want)
+}
+
+// readName reads a prefixed name, a blank node label or a keyword.
+// The trailing dots are left for the statement terminator.
+func (l *rdfLexer) readName() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if r == '\\' {
+			next, err := l.read()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+			b.WriteRune(next)
+			continue
+		}
+		if !isNameChar(r) {
+			l.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	for strings.HasSuffix(s, ".") && !strings.HasSuffix(s, `\.`) {
+		s = s[:len(s)-1]
+		l.unread('.')
+	}
+	if s == "" {
+		r, _ := l.peek()
+		return "", l.errorf("unexpected %q", r)
+	}
+	return s, nil
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || r == ':' || unicode.IsLetter(r)
+}
+
+func isNameChar(r rune) bool {
+	return isNameStart(r) || unicode.IsDigit(r) || unicode.IsMark(r) ||
+		r == '-' || r == '.' || r == '%' || r == '·'
+}
+
+func (l *rdfLexer) readLanguageTag() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if r != '-' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			l.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "", l.errorf("empty language tag")
+	}
+	return b.String(), nil
+}
+
+// readString reads a short or long quoted string and unescapes it.
+func (l *rdfLexer) readString() (string, error) {
+	quote, err := l.read()
+	if err != nil {
+		return "", err
+	}
+	long, err := l.longQuote(quote)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for {
+		r, err := l.read()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case r == quote && !long:
+			return b.String(), nil
+		case r == quote:
+			end, err := l.closeLongQuote(quote)
+			if err != nil {
+				return "", err
+			}
+			if end {
+				return b.String(), nil
+			}
+		case r == '\\':
+			if r, err = l.readEscape(true); err != nil {
+				return "", err
+			}
+		case (r == '\n' || r == '\r') && !long:
+			return "", l.errorf("unexpected line break in string")
+		}
+		b.WriteRune(r)
+	}
+}
+
+// longQuote reports whether the string is quoted with three quotes.
+// The empty string `""` is not a long quote.
+func (l *rdfLexer) longQuote(quote rune) (bool, error) {
+	r, err := l.read()
+	if err != nil {
+		return false, err
+	}
+	if r != quote {
+		l.unread(r)
+		return false, nil
+	}
+	r, err = l.read()
+	if err == io.EOF {
+		l.unread(quote)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if r != quote {
+		l.unread(r)
+		l.unread(quote)
+		return false, nil
+	}
+	return true, nil
+}
+
+// closeLongQuote reports whether the quote just read and the following two close the long string.
+func (l *rdfLexer) closeLongQuote(quote rune) (bool, error) {
+	for i := 0; i < 2; i++ {
+		r, err := l.read()
+		if err != nil {
+			return false, err
+		}
+		if r != quote {
+			l.unread(r)
+			for ; i > 0; i-- {
+				l.unread(quote)
+			}
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// readEscape reads an escape sequence after a backslash.
+// ECHAR is accepted only in strings.
+func (l *rdfLexer) readEscape(echar bool) (rune, error) {
+	r, err := l.read()
+	if err != nil {
+		return 0, err
+	}
+	seq := []rune{'\\', r}
+	switch {
+	case r == 'u':
+		seq, err = l.appendRunes(seq, 4)
+	case r == 'U':
+		seq, err = l.appendRunes(seq, 8)
+	case !echar:
+		return 0, l.errorf("malformed escape sequence %q", string(seq))
+	}
+	if err != nil {
+		return 0, err
+	}
+	e, _, err := unescape(string(seq))
+	if err != nil {
+		return 0, l.errorf("%v", err)
+	}
+	return e, nil
+}
+
+func (l *rdfLexer) appendRunes(rs []rune, n int) ([]rune, error) {
+	for i := 0; i < n; i++ {
+		r, err := l.read()
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeTurtleString(t *testing.T, s string) ([]Triple, error) {
+	t.Helper()
+	result, err := DecodeTurtle(ioutil.NopCloser(strings.NewReader(s)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	var triples []Triple
+	for {
+		triple, err := result.Next()
+		if err == io.EOF {
+			return triples, nil
+		}
+		if err != nil {
+			return triples, err
+		}
+		triples = append(triples, triple)
+	}
+}
+
+func TestTurtleParser_Format(t *testing.T) {
+	if got, want := NewTurtleParser().Format(), "text/turtle"; got != want {
+		t.Errorf("TurtleParser.Format() = %v, want %v", got, want)
+	}
+}
+
+// nolint: scopelint
+func TestDecodeTurtle(t *testing.T) {
+	ex := func(local string) URI {
+		return URI("http://example.com/" + local)
+	}
+	tests := []struct {
+		name    string
+		doc     string
+		want    []Triple
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			doc:  "# comment only\n",
+		},
+		{
+			name: "iri",
+			doc:  `<http://example.com/s> <http://example.com/p> <http://example.com/o> .`,
+			want: []Triple{{ex("s"), ex("p"), ex("o")}},
+		},
+		{
+			name: "prefix and base",
+			doc: `@prefix ex: <http://example.com/> .
+PREFIX : <http://example.com/default#>
+@base <http://example.com/base/> .
+ex:s :p <o> .
+BASE <http://example.org/>
+<s> a ex:C .`,
+			want: []Triple{
+				{ex("s"), URI("http://example.com/default#p"), URI("http://example.com/base/o")},
+				{URI("http://example.org/s"), URI(rdfType), ex("C")},
+			},
+		},
+		{
+			name: "predicate and object lists",
+			doc: `@prefix ex: <http://example.com/> .
+ex:s ex:p ex:o1 , ex:o2 ;
+     ex:q ex:o3 ; .`,
+			want: []Triple{
+				{ex("s"), ex("p"), ex("o1")},
+				{ex("s"), ex("p"), ex("o2")},
+				{ex("s"), ex("q"), ex("o3")},
+			},
+		},
+		{
+			name: "literals",
+			doc: `@prefix ex: <http://example.com/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:s ex:p "a\tb" , 'c' , """d
+"e""" , "f"@en-US , "1"^^xsd:int , "2"^^<http://example.com/dt> ,
+  1 , -1.5 , 1e3 , true , "" .`,
+			want: []Triple{
+				{ex("s"), ex("p"), Literal{Value: "a\tb"}},
+				{ex("s"), ex("p"), Literal{Value: "c"}},
+				{ex("s"), ex("p"), Literal{Value: "d\n\"e"}},
+				{ex("s"), ex("p"), Literal{Value: "f", LanguageTag: "en-US"}},
+				{ex("s"), ex("p"), Literal{Value: "1", DataType: URI("http://www.w3.org/2001/XMLSchema#int")}},
+				{ex("s"), ex("p"), Literal{Value: "2", DataType: ex("dt")}},
+				{ex("s"), ex("p"), Literal{Value: "1", DataType: URI(xsdInteger)}},
+				{ex("s"), ex("p"), Literal{Value: "-1.5", DataType: URI(xsdDecimal)}},
+				{ex("s"), ex("p"), Literal{Value: "1e3", DataType: URI(xsdDouble)}},
+				{ex("s"), ex("p"), Literal{Value: "true", DataType: URI(xsdBoolean)}},
+				{ex("s"), ex("p"), Literal{Value: ""}},
+			},
+		},
+		{
+			name: "number at the end",
+			doc:  `<http://example.com/s> <http://example.com/p> 1.`,
+			want: []Triple{{ex("s"), ex("p"), Literal{Value: "1", DataType: URI(xsdInteger)}}},
+		},
+		{
+			name: "blank nodes",
+			doc: `@prefix ex: <http://example.com/> .
+_:b0 ex:p [ ex:q ex:o ] .
+[ ex:r "x" ] .
+[] ex:p _:b1.`,
+			want: []Triple{
+				{BNode("genid1"), ex("q"), ex("o")},
+				{BNode("b0"), ex("p"), BNode("genid1")},
+				{BNode("genid2"), ex("r"), Literal{Value: "x"}},
+				{BNode("genid3"), ex("p"), BNode("b1")},
+			},
+		},
+		{
+			name: "blank node labels like generated ones",
+			doc:  `_:genid1 <http://example.com/p> [ <http://example.com/q> _:genid ] .`,
+			want: []Triple{
+				{BNode("genid1"), ex("q"), BNode("genid-genid")},
+				{BNode("genid-genid1"), ex("p"), BNode("genid1")},
+			},
+		},
+		{
+			name: "collections",
+			doc: `@prefix ex: <http://example.com/> .
+ex:s ex:p ( ex:a [ ex:q 1 ] ) , () .`,
+			want: []Triple{
+				{BNode("genid1"), URI(rdfFirst), ex("a")},
+				{BNode("genid1"), URI(rdfRest), BNode("genid2")},
+				{BNode("genid2"), URI(rdfFirst), BNode("genid3")},
+				{BNode("genid3"), ex("q"), Literal{Value: "1", DataType: URI(xsdInteger)}},
+				{BNode("genid2"), URI(rdfRest), URI(rdfNil)},
+				{ex("s"), ex("p"), BNode("genid1")},
+				{ex("s"), ex("p"), URI(rdfNil)},
+			},
+		},
+		{
+			name: "escapes",
+			doc:  `@prefix ex: <http://example.com/> . ex:s\.1 <http://example.com/p> "\U0001F600".`,
+			want: []Triple{{ex("s.1"), ex("p"), Literal{Value: "😀"}}},
+		},
+		{
+			name:    "undefined prefix",
+			doc:     `ex:s ex:p ex:o .`,
+			wantErr: true,
+		},
+		{
+			name:    "unexpected EOF",
+			doc:     `<http://example.com/s> <http://example.com/p>`,
+			wantErr: true,
+		},
+		{
+			name:    "missing dot",
+			doc:     `<http://example.com/s> <http://example.com/p> <http://example.com/o>`,
+			want:    []Triple{{ex("s"), ex("p"), ex("o")}},
+			wantErr: true,
+		},
+		{
+			name:    "literal subject",
+			doc:     `"s" <http://example.com/p> <http://example.com/o> .`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown directive",
+			doc:     `@foo <http://example.com/> .`,
+			wantErr: true,
+		},
+		{
+			name: "error after triples",
+			doc: `<http://example.com/s> <http://example.com/p> <http://example.com/o> .
+<http://example.com/s> <http://example.com/p> "unterminated .`,
+			want:    []Triple{{ex("s"), ex("p"), ex("o")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTurtleString(t, tt.doc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeTurtle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeTurtle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTurtle_errorLine(t *testing.T) {
+	_, err := decodeTurtleString(t, "@prefix ex: <http://example.com/> .\n\nex:s ex:p foo:o .\n")
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("DecodeTurtle() error = %v", err)
+	}
+}
+
+func TestTurtleGraphResult_Close(t *testing.T) {
+	result, err := DecodeTurtle(ioutil.NopCloser(strings.NewReader("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Close(); err != nil {
+		t.Errorf("TurtleGraphResult.Close() error = %v", err)
+	}
+}
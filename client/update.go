@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// Update sends SPARQL Update to the update endpoint.
+func (c *Client) Update(
+	ctx context.Context,
+	update string,
+	params ...Param,
+) error {
+	return c.Prepare(update).Update(ctx, params...)
+}
+
+// updateEndpoint returns the endpoint for SPARQL Update requests.
+func (c *Client) updateEndpoint() string {
+	if c.UpdateEndpoint != "" {
+		return c.UpdateEndpoint
+	}
+	return c.Endpoint
+}
+
+// Update sends the statement to the endpoint as a SPARQL 1.1 Update request.
+func (s *Statement) Update(ctx context.Context, params ...Param) (err error) {
+	if err := s.validated("Update", parser.FormUpdate); err != nil {
+		return err
+	}
+	request, err := s.updateRequest(ctx, params...)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.c.send(request, true, func(resp *http.Response) error {
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		return s.c.newHTTPError("update", s.c.updateEndpoint(), s.text(params), resp)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := resp.Body.Close(); err2 != nil && err == nil {
+			err = err2
+		}
+	}()
+
+	// Drain the body to reuse the connection.
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+// updateRequest builds a POST request with the `application/sparql-update` body.
+func (s *Statement) updateRequest(ctx context.Context, params ...Param) (*http.Request, error) {
+	const defaultBufferSize = 1024
+	b := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+
+	if err := s.compose(b, params...); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, s.c.updateEndpoint(), b)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/sparql-update")
+	s.c.setCredentials(request)
+	return request.WithContext(ctx), nil
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Update(t *testing.T) {
+	t.Run("query endpoint", func(t *testing.T) {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			},
+		))
+
+		c, err := New(server.URL + "/query")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Update(context.Background(), "CLEAR ALL"); err != nil {
+			t.Errorf("Client.Update() error = %v", err)
+			return
+		}
+		if want := "/query"; path != want {
+			t.Errorf("path = %v, want %v", path, want)
+		}
+	})
+	t.Run("update endpoint", func(t *testing.T) {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			},
+		))
+
+		c, err := New(server.URL+"/query", WithUpdateEndpoint(server.URL+"/update"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Update(context.Background(), "CLEAR ALL"); err != nil {
+			t.Errorf("Client.Update() error = %v", err)
+			return
+		}
+		if want := "/update"; path != want {
+			t.Errorf("path = %v, want %v", path, want)
+		}
+	})
+}
+
+func TestStatement_Update(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		c, err := New("foo")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err := c.Prepare("").Update(context.Background()); err == nil {
+			t.Errorf("Statement.Update() error = %v", err)
+		}
+	})
+	t.Run("not ok", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "", http.StatusBadRequest)
+			},
+		))
+
+		c := &Client{
+			HTTPClient: *server.Client(),
+			Endpoint:   server.URL,
+		}
+		if err := c.Prepare("").Update(context.Background()); err == nil {
+			t.Errorf("Statement.Update() error = %v", err)
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		var method, contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				contentType = r.Header.Get("Content-Type")
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				body = string(b)
+				w.WriteHeader(http.StatusNoContent)
+			},
+		))
+
+		c := &Client{
+			HTTPClient: *server.Client(),
+			Endpoint:   server.URL,
+			prefixes:   map[string]URI{"foo": "http://example.com/"},
+		}
+		err := c.Prepare(`INSERT DATA { foo:s foo:p $1 }`).Update(context.Background(), Param{
+			Ordinal: 1,
+			Value:   1,
+		})
+		if err != nil {
+			t.Errorf("Statement.Update() error = %v", err)
+			return
+		}
+		if method != http.MethodPost {
+			t.Errorf("method = %v, want %v", method, http.MethodPost)
+		}
+		if want := "application/sparql-update"; contentType != want {
+			t.Errorf("Content-Type = %v, want %v", contentType, want)
+		}
+		if want := "PREFIX foo: <http://example.com/>\nINSERT DATA { foo:s foo:p 1 }"; body != want {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+	})
+}
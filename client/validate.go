@@ -0,0 +1,57 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// WithValidation makes statements parse their queries on `Client.Prepare`.
+// Statements with syntax errors fail without sending requests, and statements of
+// wrong forms, such as an update sent by Query, fail as well. The prefixes of
+// `WithPrefix` needn't be declared in the queries.
+func WithValidation() Option {
+	return func(c *Client) error {
+		c.validate = true
+		return nil
+	}
+}
+
+// Parse parses the query of the statement. The positions of the syntax tree and
+// `*parser.SyntaxError` are in the query without the prefixes of `WithPrefix`.
+func (s *Statement) Parse() (parser.Node, error) {
+	return parser.Parse(s.query)
+}
+
+// Validate returns `*parser.SyntaxError` if the query is not valid SPARQL 1.1 Query or Update.
+func (s *Statement) Validate() error {
+	_, err := s.Parse()
+	return err
+}
+
+// Form returns the form of the query. It's `parser.FormUnknown` if the form is unknown.
+func (s *Statement) Form() parser.Form {
+	form, err := parser.DetectForm(s.query)
+	if err != nil {
+		return parser.FormUnknown
+	}
+	return form
+}
+
+// validated returns the validation error of `Client.Prepare` or an error if the form
+// is not one of the forms which the method sends. It does nothing without `WithValidation`.
+func (s *Statement) validated(method string, forms ...parser.Form) error {
+	if !s.c.validate {
+		return nil
+	}
+	if s.err != nil {
+		return s.err
+	}
+	form := s.Form()
+	for _, f := range forms {
+		if form == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s cannot be sent by %s", form, method)
+}
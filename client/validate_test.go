@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/garsue/sparql/parser"
+)
+
+// nolint: scopelint
+func TestStatement_Form(t *testing.T) {
+	c, err := New("http://example.com/sparql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		query string
+		want  parser.Form
+	}{
+		{query: "SELECT * WHERE { ?s ?p $1 }", want: parser.FormSelect},
+		{query: "PREFIX ex: <http://example.com/> ASK { ex:a ?p ?o }", want: parser.FormAsk},
+		{query: "CONSTRUCT WHERE { ?s ?p ?o }", want: parser.FormConstruct},
+		{query: "DESCRIBE <http://example.com/a>", want: parser.FormDescribe},
+		{query: "DELETE WHERE { ?s ?p @o }", want: parser.FormUpdate},
+		{query: "FOO", want: parser.FormUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := c.Prepare(tt.query).Form(); got != tt.want {
+				t.Errorf("Statement.Form() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatement_Validate(t *testing.T) {
+	c, err := New("http://example.com/sparql", WithPrefix("ex", "http://example.com/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Prepare("SELECT * WHERE { ?s ex:p $1 }").Validate(); err != nil {
+		t.Errorf("Statement.Validate() error = %v", err)
+	}
+	err = c.Prepare("SELECT * WHERE {\n?s ex:p }").Validate()
+	serr, ok := err.(*parser.SyntaxError)
+	if !ok {
+		t.Fatalf("Statement.Validate() error = %v, want *parser.SyntaxError", err)
+	}
+	if serr.Pos.Line != 2 || serr.Pos.Column != 9 {
+		t.Errorf("Statement.Validate() error = %v, want at line 2 col 9", serr)
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			http.Error(w, "", http.StatusInternalServerError)
+		},
+	))
+	defer server.Close()
+
+	c, err := New(server.URL, WithHTTPClient(server.Client()), WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.Query(ctx, "SELECT * WHERE { ?s ?p }"); err == nil {
+		t.Error("Client.Query() of a syntax error must fail")
+	} else if _, ok := err.(*parser.SyntaxError); !ok {
+		t.Errorf("Client.Query() error = %v, want *parser.SyntaxError", err)
+	}
+	if _, err := c.Query(ctx, "CONSTRUCT WHERE { ?s ?p ?o }"); err == nil ||
+		err.Error() != "CONSTRUCT cannot be sent by Query" {
+		t.Errorf("Client.Query() error = %v", err)
+	}
+	if _, err := c.Construct(ctx, "ASK {}"); err == nil || err.Error() != "ASK cannot be sent by Construct" {
+		t.Errorf("Client.Construct() error = %v", err)
+	}
+	if err := c.Update(ctx, "SELECT * {}"); err == nil || err.Error() != "SELECT cannot be sent by Update" {
+		t.Errorf("Client.Update() error = %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("%d requests are sent, want 0", n)
+	}
+
+	// Valid statements are sent.
+	if _, err := c.Query(ctx, "ASK {}"); err == nil {
+		t.Error("Client.Query() must fail with the server error")
+	}
+	if err := c.Update(ctx, "CLEAR DEFAULT"); err == nil {
+		t.Error("Client.Update() must fail with the server error")
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("%d requests are sent, want 2", n)
+	}
+}
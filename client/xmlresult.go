@@ -4,6 +4,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+
+	"github.com/garsue/sparql/parser"
 )
 
 type (
@@ -58,6 +60,9 @@ type XMLQueryResult struct {
 	r         io.ReadCloser
 	variables []string
 	decoder   *xml.Decoder
+	// form is detected by the element following `head`.
+	form    parser.Form
+	boolean bool
 }
 
 // DecodeXMLQueryResult decodes responded XML Query Result.
@@ -201,7 +206,39 @@ func startElement(r xml.TokenReader) (xml.StartElement, error) {
 	}
 }
 
+// Form returns `parser.FormAsk` if `boolean` follows `head`, otherwise `parser.FormSelect`.
+// The boolean is kept for `Boolean`.
+func (x *XMLQueryResult) Form() (parser.Form, error) {
+	if x.form != parser.FormUnknown {
+		return x.form, nil
+	}
+	for {
+		t, err := startElement(x.decoder)
+		if err == io.EOF {
+			x.form = parser.FormSelect
+			return x.form, nil
+		}
+		if err != nil {
+			return parser.FormUnknown, err
+		}
+		switch t.Name.Local {
+		case "boolean":
+			if err := x.decoder.DecodeElement(&x.boolean, &t); err != nil {
+				return parser.FormUnknown, err
+			}
+			x.form = parser.FormAsk
+			return x.form, nil
+		case "results":
+			x.form = parser.FormSelect
+			return x.form, nil
+		}
+	}
+}
+
 func (x *XMLQueryResult) Boolean() (bool, error) {
+	if x.form == parser.FormAsk {
+		return x.boolean, nil
+	}
 	for {
 		t, err := startElement(x.decoder)
 		if err != nil {
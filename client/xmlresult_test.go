@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/garsue/sparql/parser"
 )
 
 func TestDecodeXMLQueryResult(t *testing.T) {
@@ -399,3 +401,36 @@ func TestXMLQueryResult_Close(t *testing.T) {
 		t.Errorf("XMLQueryResult.Close() error = %v", err)
 	}
 }
+
+// nolint: scopelint
+func TestXMLQueryResult_Form(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want parser.Form
+	}{
+		{name: "ask", doc: `<sparql><head/><boolean>false</boolean></sparql>`, want: parser.FormAsk},
+		{name: "select", doc: `<sparql><head/><results/></sparql>`, want: parser.FormSelect},
+		{name: "head only", doc: `<sparql><head/></sparql>`, want: parser.FormSelect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := DecodeXMLQueryResult(ioutil.NopCloser(strings.NewReader(tt.doc)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := x.(FormDetector).Form()
+			if err != nil {
+				t.Fatalf("XMLQueryResult.Form() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("XMLQueryResult.Form() = %v, want %v", got, tt.want)
+			}
+			if got == parser.FormAsk {
+				if b, err := x.Boolean(); err != nil || b {
+					t.Errorf("XMLQueryResult.Boolean() = %v, %v", b, err)
+				}
+			}
+		})
+	}
+}
@@ -3,10 +3,11 @@ package sparql
 import (
 	"context"
 	"database/sql/driver"
-	"fmt"
-	"time"
+	"io"
+	"reflect"
 
 	"github.com/garsue/sparql/client"
+	"github.com/garsue/sparql/parser"
 )
 
 // Conn connects to a SPARQL source.
@@ -14,13 +15,33 @@ type Conn struct {
 	Client *client.Client
 }
 
+// AskColumn is the name of the only column of ASK query results.
+const AskColumn = "ask"
+
 // Rows implements `driver.Rows` with `sparql.QueryResult`.
+// The result of an ASK query is a single row of the boolean in `AskColumn`.
 type Rows struct {
 	queryResult client.QueryResult
+
+	// ask reports whether the result is the boolean of an ASK query.
+	ask bool
+	// done reports whether the row of the ASK result has been returned.
+	done bool
+
+	// first is the first row. It's read ahead to infer the column types.
+	first    map[string]client.Value
+	firstErr error
+	// peeked reports whether the first row has been read.
+	peeked bool
+	// buffered reports whether the first row is read ahead and not returned by Next yet.
+	buffered bool
 }
 
 // Columns returns the names of the columns.
 func (r *Rows) Columns() []string {
+	if r.ask {
+		return []string{AskColumn}
+	}
 	return r.queryResult.Variables()
 }
 
@@ -32,9 +53,11 @@ func (r *Rows) Close() error {
 // Next is called to populate the next row of data into
 // the provided slice.
 func (r *Rows) Next(dest []driver.Value) error {
-	// See Boolean field if the query is ASK (not SELECT)
-	variables := r.queryResult.Variables()
-	if len(variables) == 0 && len(dest) > 0 {
+	if r.ask {
+		if r.done {
+			return io.EOF
+		}
+		r.done = true
 		b, err := r.queryResult.Boolean()
 		if err != nil {
 			return err
@@ -43,33 +66,138 @@ func (r *Rows) Next(dest []driver.Value) error {
 		return nil
 	}
 
-	bindings, err := r.queryResult.Next()
+	bindings, err := r.next()
 	if err != nil {
 		return err
 	}
-	for i, k := range variables {
+	for i, k := range r.queryResult.Variables() {
 		dest[i] = scan(bindings[k])
 	}
 	return nil
 }
 
+// next returns the read-ahead first row or reads the next row.
+func (r *Rows) next() (map[string]client.Value, error) {
+	if r.buffered {
+		r.buffered = false
+		return r.first, r.firstErr
+	}
+	bindings, err := r.queryResult.Next()
+	if !r.peeked {
+		r.peeked = true
+		r.first, r.firstErr = bindings, err
+	}
+	return bindings, err
+}
+
+// sample returns the value of the column in the first row. It reads the first row
+// ahead if Next has not been called yet.
+func (r *Rows) sample(index int) client.Value {
+	if !r.peeked {
+		r.peeked, r.buffered = true, true
+		r.first, r.firstErr = r.queryResult.Next()
+	}
+	variables := r.queryResult.Variables()
+	if r.firstErr != nil || index >= len(variables) {
+		return nil
+	}
+	return r.first[variables[index]]
+}
+
+var (
+	scanTypeAny     = reflect.TypeOf((*interface{})(nil)).Elem()
+	scanTypeLiteral = reflect.TypeOf(client.Literal{})
+	scanTypeBool    = reflect.TypeOf(false)
+)
+
+// ColumnTypeScanType returns the Go type of the column inferred from the first row.
+// It's `interface{}` if the variable is unbound in the first row or there are no rows.
+// It's `bool` for ASK results.
+func (r *Rows) ColumnTypeScanType(index int) reflect.Type {
+	if r.ask {
+		return scanTypeBool
+	}
+	v := r.sample(index)
+	if v == nil {
+		return scanTypeAny
+	}
+	if literal, ok := v.(client.Literal); ok {
+		if decoded, err := literal.Decode(); err == nil {
+			return reflect.TypeOf(decoded)
+		}
+		return scanTypeLiteral
+	}
+	return reflect.TypeOf(v)
+}
+
+// ColumnTypeDatabaseTypeName returns the term kind of the column inferred from the
+// first row: "URI", "BNODE" or the datatype IRI of the literal.
+// Literals without a datatype are xsd:string or rdf:langString.
+// It's the empty string if the variable is unbound in the first row or there are no rows.
+// It's xsd:boolean for ASK results.
+func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
+	if r.ask {
+		return "http://www.w3.org/2001/XMLSchema#boolean"
+	}
+	switch v := r.sample(index).(type) {
+	case client.URI:
+		return "URI"
+	case client.BNode:
+		return "BNODE"
+	case client.Literal:
+		switch dataType := v.DataType.(type) {
+		case client.URI:
+			return string(dataType)
+		case client.PrefixedName:
+			return string(dataType)
+		}
+		if v.LanguageTag != "" {
+			return "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+		}
+		return "http://www.w3.org/2001/XMLSchema#string"
+	default:
+		return ""
+	}
+}
+
+// ColumnTypeNullable always reports that the column is nullable.
+// Any variable can be unbound, for example by OPTIONAL.
+func (r *Rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return true, true
+}
+
+// isAsk reports whether the result is of an ASK query by the query form.
+// The result document tells it instead if the form of the query is unknown.
+func isAsk(form parser.Form, result client.QueryResult) (bool, error) {
+	switch form {
+	case parser.FormAsk:
+		return true, nil
+	case parser.FormSelect:
+		return false, nil
+	}
+	d, ok := result.(client.FormDetector)
+	if !ok {
+		return false, nil
+	}
+	form, err := d.Form()
+	if err != nil {
+		return false, err
+	}
+	return form == parser.FormAsk, nil
+}
+
+// scan converts a literal into a native Go value by its datatype.
+// The literal is returned as is if the conversion fails.
 func scan(b client.Value) driver.Value {
 	literal, ok := b.(client.Literal)
 	if !ok {
 		return b
 	}
-	if literal.DataType == client.URI("http://www.w3.org/2001/XMLSchema#dateTime") {
-		for _, f := range []string{
-			"2006-01-02T15:04:05.999999999",
-			time.RFC3339Nano,
-		} {
-			t, err := time.ParseInLocation(f, fmt.Sprint(literal.Value), time.UTC)
-			if err == nil {
-				return t
-			}
-		}
+	v, err := literal.Decode()
+	if err != nil {
+		return literal
 	}
-	return literal.Value
+	return v
 }
 
 // QueryContext queries to a SPARQL source.
@@ -78,14 +206,18 @@ func (c *Conn) QueryContext(
 	query string,
 	args []driver.NamedValue,
 ) (driver.Rows, error) {
-	result, err := c.Client.Query(ctx, query, argsToParams(args)...)
-	if err != nil {
-		return nil, err
-	}
+	stmt := Stmt{Statement: c.Client.Prepare(query)}
+	return stmt.QueryContext(ctx, args)
+}
 
-	return &Rows{
-		queryResult: result,
-	}, nil
+// ExecContext sends a SPARQL Update request to a SPARQL source.
+func (c *Conn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	stmt := Stmt{Statement: c.Client.Prepare(query)}
+	return stmt.ExecContext(ctx, args)
 }
 
 func argsToParams(args []driver.NamedValue) []client.Param {
@@ -100,6 +232,29 @@ func argsToParams(args []driver.NamedValue) []client.Param {
 	return params
 }
 
+// CheckNamedValue keeps RDF terms and values of the registered datatypes as they are
+// so that they are serialized as terms. Other values are converted by the default converter.
+func (c *Conn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case client.Term:
+		nv.Value = v.Term
+		return nil
+	case client.NullTerm:
+		nv.Value = nil
+		if v.Valid {
+			nv.Value = v.Term
+		}
+		return nil
+	case client.IRIRef, client.BNode, client.Literal, client.Serializable:
+		return nil
+	}
+	if literal, ok := client.EncodeLiteral(nv.Value); ok {
+		nv.Value = literal
+		return nil
+	}
+	return driver.ErrSkip
+}
+
 // Ping sends a HTTP HEAD request to the source.
 func (c *Conn) Ping(ctx context.Context) error {
 	return c.Client.Ping(ctx)
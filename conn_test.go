@@ -6,10 +6,13 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -163,6 +166,10 @@ func TestRows_Next(t *testing.T) {
 			queryResult: &mockQueryResult{
 				boolean: true,
 			},
+			ask: true,
+		}
+		if got, want := r.Columns(), []string{AskColumn}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Rows.Columns() = %v, want %v", got, want)
 		}
 		dest := make([]driver.Value, 1)
 		if err := r.Next(dest); err != nil {
@@ -172,6 +179,9 @@ func TestRows_Next(t *testing.T) {
 		if !reflect.DeepEqual(dest, want) {
 			t.Errorf("got %v want %v", dest, want)
 		}
+		if err := r.Next(dest); err != io.EOF {
+			t.Errorf("Rows.Next() error = %v, want io.EOF", err)
+		}
 	})
 	t.Run("ASK error", func(t *testing.T) {
 		askErr := errors.New("ASK error")
@@ -179,6 +189,7 @@ func TestRows_Next(t *testing.T) {
 			queryResult: &mockQueryResult{
 				err: askErr,
 			},
+			ask: true,
 		}
 		dest := make([]driver.Value, 1)
 		if err := r.Next(dest); err != askErr {
@@ -218,6 +229,219 @@ func TestRows_Next(t *testing.T) {
 	})
 }
 
+func TestRows_ColumnTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `<sparql><head>`+
+				`<variable name="s"/><variable name="n"/><variable name="l"/><variable name="b"/><variable name="x"/>`+
+				`</head><results>`+
+				`<result><binding name="s"><uri>http://example.com/s</uri></binding>`+
+				`<binding name="n"><literal datatype="http://www.w3.org/2001/XMLSchema#integer">1</literal></binding>`+
+				`<binding name="l"><literal xml:lang="en">foo</literal></binding>`+
+				`<binding name="b"><bnode>b0</bnode></binding></result>`+
+				`<result><binding name="x"><literal>bar</literal></binding></result>`+
+				`</results></sparql>`)
+		},
+	))
+	defer server.Close()
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * {}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantScanTypes := []reflect.Type{
+		reflect.TypeOf(client.URI("")),
+		reflect.TypeOf(int64(0)),
+		reflect.TypeOf(""),
+		reflect.TypeOf(client.BNode("")),
+		reflect.TypeOf((*interface{})(nil)).Elem(),
+	}
+	wantNames := []string{
+		"URI",
+		"http://www.w3.org/2001/XMLSchema#integer",
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#langString",
+		"BNODE",
+		"",
+	}
+	for i, ct := range types {
+		if got := ct.ScanType(); got != wantScanTypes[i] {
+			t.Errorf("ScanType(%d) = %v, want %v", i, got, wantScanTypes[i])
+		}
+		if got := ct.DatabaseTypeName(); got != wantNames[i] {
+			t.Errorf("DatabaseTypeName(%d) = %v, want %v", i, got, wantNames[i])
+		}
+		if nullable, ok := ct.Nullable(); !nullable || !ok {
+			t.Errorf("Nullable(%d) = %v, %v", i, nullable, ok)
+		}
+	}
+
+	var got []interface{}
+	for rows.Next() {
+		var s, n, l, b, x interface{}
+		if err := rows.Scan(&s, &n, &l, &b, &x); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s, x)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{client.URI("http://example.com/s"), nil, nil, "bar"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+// nolint: scopelint
+func TestRows_ask(t *testing.T) {
+	tests := []struct {
+		name  string
+		dsn   string
+		body  string
+		query string
+		want  bool
+	}{
+		{
+			name:  "XML",
+			body:  `<sparql><head/><boolean>true</boolean></sparql>`,
+			query: "ASK {}",
+			want:  true,
+		},
+		{
+			name:  "JSON",
+			dsn:   "?format=json",
+			body:  `{"head": {}, "boolean": true}`,
+			query: "ASK {}",
+			want:  true,
+		},
+		{
+			name:  "JSON boolean first",
+			dsn:   "?format=json",
+			body:  `{"boolean": false, "head": {}}`,
+			query: "ASK {}",
+		},
+		{
+			name:  "XML detected by the document",
+			body:  `<sparql><head><link href="x"/></head><boolean>true</boolean></sparql>`,
+			query: `DEFINE input:inference "rules" ASK {}`,
+			want:  true,
+		},
+		{
+			name:  "JSON detected by the document",
+			dsn:   "?format=json",
+			body:  `{"head": {"link": []}, "boolean": true}`,
+			query: `DEFINE input:inference "rules" ASK {}`,
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					_, _ = fmt.Fprint(w, tt.body)
+				},
+			))
+			defer server.Close()
+			db, err := sql.Open("sparql", server.URL+tt.dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			rows, err := db.Query(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+			if columns, err := rows.Columns(); err != nil || !reflect.DeepEqual(columns, []string{AskColumn}) {
+				t.Errorf("Rows.Columns() = %v, %v", columns, err)
+			}
+			var got []bool
+			for rows.Next() {
+				var b bool
+				if err := rows.Scan(&b); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, b)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if want := []bool{tt.want}; !reflect.DeepEqual(got, want) {
+				t.Errorf("rows = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRows_select_no_variables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `<sparql><head/><results><result/><result/></results></sparql>`)
+		},
+	))
+	defer server.Close()
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * {}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("%d rows, want 2", n)
+	}
+}
+
+func TestRows_QueryRow_ask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `<sparql><head/><boolean>true</boolean></sparql>`)
+		},
+	))
+	defer server.Close()
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+
+	var ok bool
+	if err := db.QueryRowContext(context.Background(), "ASK { ?s ?p ?o }").Scan(&ok); err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("QueryRowContext().Scan() = false, want true")
+	}
+}
+
+func TestRows_ColumnTypeScanType_no_rows(t *testing.T) {
+	r := &Rows{
+		queryResult: &mockQueryResult{
+			variables: []string{"foo"},
+			err:       io.EOF,
+		},
+	}
+	if got := r.ColumnTypeScanType(0); got != reflect.TypeOf((*interface{})(nil)).Elem() {
+		t.Errorf("Rows.ColumnTypeScanType() = %v", got)
+	}
+	if err := r.Next(make([]driver.Value, 1)); err != io.EOF {
+		t.Errorf("Rows.Next() error = %v", err)
+	}
+}
+
 // nolint: scopelint
 func Test_scan(t *testing.T) {
 	type args struct {
@@ -243,7 +467,29 @@ func Test_scan(t *testing.T) {
 					Value:    "1",
 				},
 			},
-			want: "1",
+			want: int64(1),
+		},
+		{
+			name: "plain literal",
+			args: args{
+				b: client.Literal{
+					Value: "foo",
+				},
+			},
+			want: "foo",
+		},
+		{
+			name: "invalid literal",
+			args: args{
+				b: client.Literal{
+					DataType: client.URI("http://www.w3.org/2001/XMLSchema#integer"),
+					Value:    "foo",
+				},
+			},
+			want: client.Literal{
+				DataType: client.URI("http://www.w3.org/2001/XMLSchema#integer"),
+				Value:    "foo",
+			},
 		},
 		{
 			name: "without timezone",
@@ -330,7 +576,7 @@ func TestConn_QueryContext(t *testing.T) {
 		c := &Conn{
 			Client: cli,
 		}
-		got, err := c.QueryContext(context.Background(), "", []driver.NamedValue{
+		got, err := c.QueryContext(context.Background(), "SELECT * WHERE { @foo ?p ?o }", []driver.NamedValue{
 			{
 				Name:    "foo",
 				Ordinal: 0,
@@ -347,6 +593,193 @@ func TestConn_QueryContext(t *testing.T) {
 	})
 }
 
+func TestRows_Close_reuse(t *testing.T) {
+	const rows = 30000
+	var conns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `<sparql><head><variable name="x"/></head><results>`)
+			for i := 0; i < rows; i++ {
+				_, _ = fmt.Fprintf(w, `<result><binding name="x"><literal>%064d</literal></binding></result>`, i)
+			}
+			_, _ = fmt.Fprint(w, `</results></sparql>`)
+		},
+	))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+	for i := 0; i < 3; i++ {
+		rs, err := db.QueryContext(context.Background(), "SELECT * {}")
+		if err != nil {
+			t.Fatalf("DB.QueryContext() error = %v", err)
+		}
+		n := 0
+		for rs.Next() {
+			var x string
+			if err := rs.Scan(&x); err != nil {
+				t.Fatalf("Rows.Scan() error = %v", err)
+			}
+			n++
+		}
+		if err := rs.Err(); err != nil {
+			t.Fatalf("Rows.Err() error = %v", err)
+		}
+		if n != rows {
+			t.Errorf("rows = %d, want %d", n, rows)
+		}
+		if err := rs.Close(); err != nil {
+			t.Fatalf("Rows.Close() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&conns); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
+func TestRows_Next_deadline(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `<sparql><head><variable name="x"/></head><results>`+
+				`<result><binding name="x"><literal>1</literal></binding></result>`)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-stop:
+			}
+		},
+	))
+	defer server.Close()
+
+	cli, err := client.New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Conn{
+		Client: cli,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	rs, err := c.QueryContext(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Conn.QueryContext() error = %v", err)
+	}
+	dest := make([]driver.Value, 1)
+	if err := rs.Next(dest); err != nil {
+		t.Fatalf("Rows.Next() error = %v", err)
+	}
+	if err := rs.Next(dest); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, client.ErrTimeout) {
+		t.Errorf("Rows.Next() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := rs.Close(); err != nil {
+		t.Errorf("Rows.Close() error = %v", err)
+	}
+}
+
+func TestConn_ExecContext(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		cli, err := client.New("foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &Conn{
+			Client: cli,
+		}
+		if _, err := c.ExecContext(context.Background(), "", nil); err == nil {
+			t.Errorf("Conn.ExecContext() error = %v", err)
+			return
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+		))
+		cli, err := client.New(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &Conn{
+			Client: cli,
+		}
+		got, err := c.ExecContext(context.Background(), "DELETE WHERE { ?s ?p $1 }", []driver.NamedValue{
+			{
+				Ordinal: 1,
+				Value:   1,
+			},
+		})
+		if err != nil {
+			t.Errorf("Conn.ExecContext() error = %v", err)
+			return
+		}
+		if _, ok := got.(Result); !ok {
+			t.Errorf("Conn.ExecContext() = %+v", got)
+		}
+	})
+}
+
+func TestConn_CheckNamedValue(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query().Get("query")
+			_, _ = fmt.Fprint(w, `<sparql><head><variable name="s"/><variable name="o"/><variable name="n"/></head>`+
+				`<results><result><binding name="s"><uri>http://example.com/s</uri></binding>`+
+				`<binding name="n"><literal datatype="http://www.w3.org/2001/XMLSchema#integer">1</literal></binding>`+
+				`</result></results></sparql>`)
+		},
+	))
+	defer server.Close()
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+
+	var (
+		s client.URI
+		o client.NullTerm
+		n client.Literal
+	)
+	err := db.QueryRow(
+		"SELECT * WHERE { $1 ?p $2 . OPTIONAL { ?s ?q ?o } }",
+		client.URI("http://example.com/s"),
+		client.Term{Term: client.Literal{Value: "foo", LanguageTag: "en"}},
+	).Scan(&s, &o, &n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * WHERE { <http://example.com/s> ?p """foo"""@en . OPTIONAL { ?s ?q ?o } }`; query != want {
+		t.Errorf("query = %v, want %v", query, want)
+	}
+	if want := client.URI("http://example.com/s"); s != want {
+		t.Errorf("Scan() = %v, want %v", s, want)
+	}
+	if o.Valid {
+		t.Errorf("Scan() = %+v, want NULL", o)
+	}
+	if want := (client.Literal{Value: "1", DataType: client.URI("http://www.w3.org/2001/XMLSchema#integer")}); n != want {
+		t.Errorf("Scan() = %+v, want %+v", n, want)
+	}
+
+	if err := db.QueryRow("SELECT * WHERE { ?s ?p $1 . ?s ?q $2 }", client.BNode("b1"),
+		client.Term{Term: client.BNode("b2")}).Scan(&s, &o, &n); err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * WHERE { ?s ?p _:b1 . ?s ?q _:b2 }`; query != want {
+		t.Errorf("query = %v, want %v", query, want)
+	}
+	if err := db.QueryRow("SELECT * WHERE { ?s ?p $1 }", client.BNode("x . } ; DROP ALL #")).Scan(&s, &o, &n); err == nil {
+		t.Error("QueryRow() with an invalid blank node label succeeded")
+	}
+}
+
 func TestConn_Ping(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -2,9 +2,14 @@ package sparql
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 )
 
+func init() {
+	sql.Register("sparql", &Driver{})
+}
+
 // Driver accesses SPARQL sources.
 type Driver struct{}
 
@@ -18,8 +23,29 @@ func (d *Driver) Open(name string) (driver.Conn, error) {
 }
 
 // OpenConnector returns `driver.Connector`.
+//
+// The name is the endpoint URL. The following query parameters are
+// removed from it and used as `client.Option`s.
+//
+//	update    SPARQL Update endpoint URL
+//	prefix    global PREFIX as `name:uri`. It can be repeated.
+//	format    result format: xml, json, csv or tsv
+//	method    query method: get, post, post-direct or auto
+//	timeout   HTTP client timeout parsed by `time.ParseDuration`
+//	attempts  max attempts of queries by `client.RetryPolicy`
+//	user      user name for HTTP Basic Authentication
+//	password  password for HTTP Basic Authentication
+//
+// e.g. `https://example.com/sparql?format=json&prefix=foaf:http://xmlns.com/foaf/0.1/`
+//
+// Write `#` in the values as `%23` such as `prefix=rdf:http://www.w3.org/1999/02/22-rdf-syntax-ns%23`.
+// The name with a fragment is an error.
 func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
-	connector := NewConnector(name)
+	endpoint, opts, err := parseDSN(name)
+	if err != nil {
+		return nil, err
+	}
+	connector := NewConnector(endpoint, opts...)
 	connector.driver = d
 	return connector, nil
 }
@@ -1,9 +1,49 @@
 package sparql
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 )
 
+func TestRegister(t *testing.T) {
+	var user, password, format string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			user, password, _ = r.BasicAuth()
+			format = r.URL.Query().Get("format")
+			_, _ = fmt.Fprint(w, `<sparql><head><variable name="x"/></head>`+
+				`<results><result><binding name="x"><literal>1</literal></binding></result></results></sparql>`)
+		},
+	))
+	defer server.Close()
+
+	db, err := sql.Open("sparql", server.URL+"?format=xml&user=foo&password="+url.QueryEscape("b&r"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var x string
+	if err := db.QueryRowContext(context.Background(), "SELECT * {}").Scan(&x); err != nil {
+		t.Errorf("Scan() error = %v", err)
+		return
+	}
+	if x != "1" {
+		t.Errorf("Scan() = %v, want 1", x)
+	}
+	if user != "foo" || password != "b&r" {
+		t.Errorf("BasicAuth() = %v %v, want foo b&r", user, password)
+	}
+	if want := "application/sparql-results+xml"; format != want {
+		t.Errorf("format = %v, want %v", format, want)
+	}
+}
+
 func TestDriver_Open(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		d := &Driver{}
@@ -20,6 +60,9 @@ func TestDriver_Open(t *testing.T) {
 
 func TestDriver_OpenConnector(t *testing.T) {
 	d := &Driver{}
+	if _, err := d.OpenConnector("http://example.com/?format=rdf"); err == nil {
+		t.Errorf("Driver.OpenConnector() error = %v", err)
+	}
 	got, err := d.OpenConnector("name")
 	if err != nil {
 		t.Errorf("Driver.OpenConnector() error = %v", err)
@@ -0,0 +1,115 @@
+package sparql
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garsue/sparql/client"
+)
+
+// DSN parameters. See `Driver.OpenConnector`.
+const (
+	dsnUpdate   = "update"
+	dsnPrefix   = "prefix"
+	dsnFormat   = "format"
+	dsnMethod   = "method"
+	dsnTimeout  = "timeout"
+	dsnUser     = "user"
+	dsnPassword = "password"
+	dsnAttempts = "attempts"
+)
+
+var resultParsers = map[string]func() client.ResultParser{
+	"xml":  client.NewXMLResultParser,
+	"json": client.NewJSONResultParser,
+	"csv":  client.NewCSVResultParser,
+	"tsv":  client.NewTSVResultParser,
+}
+
+var queryMethods = map[string]client.QueryMethod{
+	"get":         client.QueryGet,
+	"post":        client.QueryPostForm,
+	"post-direct": client.QueryPostDirect,
+	"auto":        client.QueryAuto,
+}
+
+// parseDSN splits the data source name into the endpoint and `client.Option`s.
+// nolint: gocyclo
+func parseDSN(dsn string) (string, []client.Option, error) {
+	// An empty fragment is also rejected while `url.URL` doesn't keep it.
+	if i := strings.IndexByte(dsn, '#'); i >= 0 {
+		return "", nil, fmt.Errorf("unexpected fragment %q. write # in parameters as %%23", dsn[i:])
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", nil, err
+	}
+	query := u.Query()
+
+	var opts []client.Option
+	if user, password := query.Get(dsnUser), query.Get(dsnPassword); user != "" || password != "" {
+		opts = append(opts, client.WithBasicAuth(user, password))
+	}
+	query.Del(dsnUser)
+	query.Del(dsnPassword)
+	for key, values := range query {
+		value := values[len(values)-1]
+		switch key {
+		case dsnUpdate:
+			opts = append(opts, client.WithUpdateEndpoint(value))
+		case dsnPrefix:
+			for _, v := range values {
+				opt, err := prefixOption(v)
+				if err != nil {
+					return "", nil, err
+				}
+				opts = append(opts, opt)
+			}
+		case dsnFormat:
+			newParser, ok := resultParsers[value]
+			if !ok {
+				return "", nil, fmt.Errorf("unknown format %q", value)
+			}
+			opts = append(opts, client.WithResultParser(newParser()))
+		case dsnMethod:
+			method, ok := queryMethods[value]
+			if !ok {
+				return "", nil, fmt.Errorf("unknown method %q", value)
+			}
+			opts = append(opts, client.WithQueryMethod(method))
+		case dsnTimeout:
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return "", nil, err
+			}
+			opts = append(opts, client.WithHTTPClient(&http.Client{Timeout: timeout}))
+		case dsnAttempts:
+			attempts, err := strconv.Atoi(value)
+			if err != nil {
+				return "", nil, err
+			}
+			opts = append(opts, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: attempts}))
+		default:
+			continue
+		}
+		query.Del(key)
+	}
+	if len(opts) == 0 {
+		return dsn, nil, nil
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String(), opts, nil
+}
+
+func prefixOption(v string) (client.Option, error) {
+	i := strings.Index(v, ":")
+	if i < 0 {
+		return nil, fmt.Errorf("malformed prefix %q", v)
+	}
+	return client.WithPrefix(v[:i], client.URI(v[i+1:])), nil
+}
@@ -0,0 +1,61 @@
+package sparql
+
+import (
+	"testing"
+)
+
+// nolint: scopelint
+func Test_parseDSN(t *testing.T) {
+	tests := []struct {
+		name         string
+		dsn          string
+		wantEndpoint string
+		wantOpts     int
+		wantErr      bool
+	}{
+		{
+			name:         "endpoint only",
+			dsn:          "http://example.com/sparql?default-graph-uri=http%3A%2F%2Fexample.com%2F",
+			wantEndpoint: "http://example.com/sparql?default-graph-uri=http%3A%2F%2Fexample.com%2F",
+		},
+		{
+			name: "all",
+			dsn: "http://example.com/sparql?graph=g" +
+				"&update=http%3A%2F%2Fexample.com%2Fupdate" +
+				"&prefix=foaf:http://xmlns.com/foaf/0.1/" +
+				"&prefix=dc:http://purl.org/dc/elements/1.1/" +
+				"&format=json&method=auto&timeout=5s&attempts=3&user=foo&password=bar",
+			wantEndpoint: "http://example.com/sparql?graph=g",
+			wantOpts:     8,
+		},
+		{name: "bad URL", dsn: "http://example.com/%zz", wantErr: true},
+		{name: "bad prefix", dsn: "http://example.com/?prefix=foo", wantErr: true},
+		{name: "bad format", dsn: "http://example.com/?format=rdf", wantErr: true},
+		{name: "bad method", dsn: "http://example.com/?method=put", wantErr: true},
+		{name: "bad timeout", dsn: "http://example.com/?timeout=5", wantErr: true},
+		{name: "bad attempts", dsn: "http://example.com/?attempts=many", wantErr: true},
+		{name: "fragment", dsn: "http://example.com/?prefix=rdf:http://www.w3.org/1999/02/22-rdf-syntax-ns#", wantErr: true},
+		{name: "fragment after prefix", dsn: "http://example.com/?prefix=ex:http://example.com/#&format=json", wantErr: true},
+		{
+			name:         "escaped hash",
+			dsn:          "http://example.com/?prefix=rdf:http://www.w3.org/1999/02/22-rdf-syntax-ns%23",
+			wantEndpoint: "http://example.com/",
+			wantOpts:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, opts, err := parseDSN(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDSN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if endpoint != tt.wantEndpoint {
+				t.Errorf("parseDSN() endpoint = %v, want %v", endpoint, tt.wantEndpoint)
+			}
+			if len(opts) != tt.wantOpts {
+				t.Errorf("parseDSN() len(opts) = %v, want %v", len(opts), tt.wantOpts)
+			}
+		})
+	}
+}
@@ -1 +1,3 @@
 module github.com/garsue/sparql
+
+go 1.13
@@ -0,0 +1,397 @@
+package parser
+
+// Node is a node of the syntax tree.
+type Node interface {
+	// Pos returns the position of the first token of the node.
+	Pos() Pos
+}
+
+type base struct {
+	pos Pos
+}
+
+func (b base) Pos() Pos {
+	return b.pos
+}
+
+// Form is the form of a query or an update.
+type Form int
+
+const (
+	// FormUnknown is not a known form.
+	FormUnknown Form = iota
+	// FormSelect is SELECT.
+	FormSelect
+	// FormAsk is ASK.
+	FormAsk
+	// FormConstruct is CONSTRUCT.
+	FormConstruct
+	// FormDescribe is DESCRIBE.
+	FormDescribe
+	// FormUpdate is SPARQL Update.
+	FormUpdate
+)
+
+func (f Form) String() string {
+	switch f {
+	case FormSelect:
+		return "SELECT"
+	case FormAsk:
+		return "ASK"
+	case FormConstruct:
+		return "CONSTRUCT"
+	case FormDescribe:
+		return "DESCRIBE"
+	case FormUpdate:
+		return "UPDATE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Prologue is the BASE and PREFIX declarations.
+type Prologue struct {
+	Base     string
+	Prefixes []*PrefixDecl
+}
+
+// PrefixDecl is a PREFIX declaration.
+type PrefixDecl struct {
+	base
+	// Prefix is the prefix without the colon.
+	Prefix string
+	IRI    string
+}
+
+// Query is a query.
+type Query struct {
+	base
+	Prologue
+	Form     Form
+	Distinct bool
+	Reduced  bool
+	// All is true for `SELECT *` and `DESCRIBE *`.
+	All bool
+	// Projection is the projection of SELECT.
+	Projection []*Projection
+	// Template is the template of CONSTRUCT.
+	Template []*Triples
+	// Describe is the resources of DESCRIBE.
+	Describe []Node
+	Dataset  []*DatasetClause
+	// Where is nil if DESCRIBE has no WHERE clause.
+	Where   *GroupPattern
+	GroupBy []*Projection
+	Having  []Node
+	OrderBy []*OrderCondition
+	// Limit and Offset are nil if they are omitted.
+	Limit  *int64
+	Offset *int64
+	Values *Values
+}
+
+// Projection is a variable or `(expression AS ?var)`. Expr is nil for a variable.
+// Var is nil for a GROUP BY condition without AS.
+type Projection struct {
+	base
+	Expr Node
+	Var  *Var
+}
+
+// DatasetClause is FROM, FROM NAMED, USING or USING NAMED.
+type DatasetClause struct {
+	base
+	Named bool
+	IRI   Node
+}
+
+// OrderCondition is an ORDER BY condition.
+type OrderCondition struct {
+	base
+	Desc bool
+	Expr Node
+}
+
+// Var is a variable.
+type Var struct {
+	base
+	Name string
+}
+
+// Placeholder is an ordinal placeholder `$n` or a named placeholder `@name`.
+// Name has the `$` or `@`.
+type Placeholder struct {
+	base
+	Name string
+}
+
+// IRI is an IRI reference as written without the angle brackets.
+type IRI struct {
+	base
+	Value string
+}
+
+// PrefixedName is a prefixed name such as `rdf:type`.
+type PrefixedName struct {
+	base
+	Prefix string
+	Local  string
+}
+
+// BlankNode is a labeled blank node or `[]`. Label is empty for `[]`.
+type BlankNode struct {
+	base
+	Label string
+}
+
+// Literal is a string, numeric or boolean literal. Datatype is nil for simple and
+// language-tagged literals. Numeric and boolean literals have the XSD datatypes.
+type Literal struct {
+	base
+	Value    string
+	Lang     string
+	Datatype Node
+}
+
+// Nil is the empty collection `()`.
+type Nil struct {
+	base
+}
+
+// Collection is the RDF collection `( items )`.
+type Collection struct {
+	base
+	Items []Node
+}
+
+// BlankNodePropertyList is `[ properties ]`.
+type BlankNodePropertyList struct {
+	base
+	Properties []*Property
+}
+
+// Triples is triples of the same subject.
+type Triples struct {
+	base
+	Subject    Node
+	Properties []*Property
+}
+
+// Property is a predicate and its objects. Verb is a term, a variable or a path.
+// `a` is the IRI of rdf:type.
+type Property struct {
+	base
+	Verb    Node
+	Objects []Node
+}
+
+// PathAlternative is `a | b`.
+type PathAlternative struct {
+	base
+	Paths []Node
+}
+
+// PathSequence is `a / b`.
+type PathSequence struct {
+	base
+	Paths []Node
+}
+
+// PathInverse is `^a`.
+type PathInverse struct {
+	base
+	Path Node
+}
+
+// PathMod is `a?`, `a*` or `a+`.
+type PathMod struct {
+	base
+	Path Node
+	Mod  string
+}
+
+// PathNegated is `!a` or `!(a | ^b)`. The paths are IRIs or inverses of them.
+type PathNegated struct {
+	base
+	Paths []Node
+}
+
+// GroupPattern is a group graph pattern `{ ... }`. SubSelect is non-nil for a
+// subquery and then Elements is empty.
+type GroupPattern struct {
+	base
+	Elements  []Node
+	SubSelect *Query
+}
+
+// OptionalPattern is `OPTIONAL { ... }`.
+type OptionalPattern struct {
+	base
+	Pattern *GroupPattern
+}
+
+// UnionPattern is `{ ... } UNION { ... }`.
+type UnionPattern struct {
+	base
+	Patterns []*GroupPattern
+}
+
+// MinusPattern is `MINUS { ... }`.
+type MinusPattern struct {
+	base
+	Pattern *GroupPattern
+}
+
+// GraphPattern is `GRAPH name { ... }`.
+type GraphPattern struct {
+	base
+	Name    Node
+	Pattern *GroupPattern
+}
+
+// ServicePattern is `SERVICE SILENT? name { ... }`.
+type ServicePattern struct {
+	base
+	Silent  bool
+	Name    Node
+	Pattern *GroupPattern
+}
+
+// Filter is `FILTER constraint`.
+type Filter struct {
+	base
+	Expr Node
+}
+
+// Bind is `BIND (expression AS ?var)`.
+type Bind struct {
+	base
+	Expr Node
+	Var  *Var
+}
+
+// Values is the inline data. A nil value in the rows is UNDEF.
+type Values struct {
+	base
+	Vars []*Var
+	Rows [][]Node
+}
+
+// BinaryExpr is a binary operation such as `a && b` and `a + b`.
+type BinaryExpr struct {
+	base
+	Op string
+	X  Node
+	Y  Node
+}
+
+// UnaryExpr is `!x`, `+x` or `-x`.
+type UnaryExpr struct {
+	base
+	Op string
+	X  Node
+}
+
+// InExpr is `x IN (list)` or `x NOT IN (list)`.
+type InExpr struct {
+	base
+	Not  bool
+	X    Node
+	List []Node
+}
+
+// CallExpr is a call of a built-in function, an aggregate or an IRI function.
+// Name is the upper case name of the built-in function or aggregate. Function is
+// the IRI of the function otherwise.
+type CallExpr struct {
+	base
+	Name     string
+	Function Node
+	Args     []Node
+	Distinct bool
+	// Star is true for `COUNT(*)`.
+	Star bool
+	// Separator is the separator of GROUP_CONCAT. It's nil if it's omitted.
+	Separator *string
+}
+
+// ExistsExpr is `EXISTS { ... }` or `NOT EXISTS { ... }`.
+type ExistsExpr struct {
+	base
+	Not     bool
+	Pattern *GroupPattern
+}
+
+// Update is an update request.
+type Update struct {
+	base
+	Prologue
+	Operations []Node
+}
+
+// Load is `LOAD SILENT? iri (INTO GRAPH iri)?`. Into is nil if it's omitted.
+type Load struct {
+	base
+	Silent bool
+	Source Node
+	Into   Node
+}
+
+// GraphKeyword is `DEFAULT`, `NAMED` or `ALL` in the place of a graph.
+type GraphKeyword struct {
+	base
+	Name string
+}
+
+// GraphManagement is CLEAR, DROP or CREATE. Target is an IRI or a `GraphKeyword`.
+type GraphManagement struct {
+	base
+	Op     string
+	Silent bool
+	Target Node
+}
+
+// GraphTransfer is ADD, MOVE or COPY. The graphs are IRIs or `DEFAULT`.
+type GraphTransfer struct {
+	base
+	Op     string
+	Silent bool
+	From   Node
+	To     Node
+}
+
+// GraphQuads is `GRAPH name { triples }` in quads.
+type GraphQuads struct {
+	base
+	Name    Node
+	Triples []*Triples
+}
+
+// InsertData is `INSERT DATA { quads }`. The quads are `Triples` and `GraphQuads`.
+type InsertData struct {
+	base
+	Quads []Node
+}
+
+// DeleteData is `DELETE DATA { quads }`.
+type DeleteData struct {
+	base
+	Quads []Node
+}
+
+// DeleteWhere is `DELETE WHERE { quads }`.
+type DeleteWhere struct {
+	base
+	Quads []Node
+}
+
+// Modify is `WITH iri DELETE { quads } INSERT { quads } USING iri WHERE { ... }`.
+// With is nil if it's omitted. Delete and Insert are nil if the clauses are omitted.
+type Modify struct {
+	base
+	With   Node
+	Delete []Node
+	Insert []Node
+	Using  []*DatasetClause
+	Where  *GroupPattern
+}
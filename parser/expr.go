@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"strings"
+)
+
+// builtins is the numbers of the arguments of the built-in functions.
+// The maximum is -1 for variadic functions.
+var builtins = map[string][2]int{
+	"STR": {1, 1}, "LANG": {1, 1}, "LANGMATCHES": {2, 2}, "DATATYPE": {1, 1}, "BOUND": {1, 1},
+	"IRI": {1, 1}, "URI": {1, 1}, "BNODE": {0, 1}, "RAND": {0, 0}, "ABS": {1, 1},
+	"CEIL": {1, 1}, "FLOOR": {1, 1}, "ROUND": {1, 1}, "CONCAT": {0, -1}, "SUBSTR": {2, 3},
+	"STRLEN": {1, 1}, "REPLACE": {3, 4}, "UCASE": {1, 1}, "LCASE": {1, 1}, "ENCODE_FOR_URI": {1, 1},
+	"CONTAINS": {2, 2}, "STRSTARTS": {2, 2}, "STRENDS": {2, 2}, "STRBEFORE": {2, 2}, "STRAFTER": {2, 2},
+	"YEAR": {1, 1}, "MONTH": {1, 1}, "DAY": {1, 1}, "HOURS": {1, 1}, "MINUTES": {1, 1},
+	"SECONDS": {1, 1}, "TIMEZONE": {1, 1}, "TZ": {1, 1}, "NOW": {0, 0}, "UUID": {0, 0},
+	"STRUUID": {0, 0}, "MD5": {1, 1}, "SHA1": {1, 1}, "SHA256": {1, 1}, "SHA384": {1, 1},
+	"SHA512": {1, 1}, "COALESCE": {0, -1}, "IF": {3, 3}, "STRLANG": {2, 2}, "STRDT": {2, 2},
+	"SAMETERM": {2, 2}, "ISIRI": {1, 1}, "ISURI": {1, 1}, "ISBLANK": {1, 1}, "ISLITERAL": {1, 1},
+	"ISNUMERIC": {1, 1}, "REGEX": {2, 3},
+}
+
+var aggregates = map[string]bool{
+	"COUNT": true, "SUM": true, "MIN": true, "MAX": true, "AVG": true, "SAMPLE": true, "GROUP_CONCAT": true,
+}
+
+// isCall reports whether the current token starts a call of a built-in function,
+// an aggregate or an IRI function.
+func (p *parser) isCall() bool {
+	t := p.tok()
+	switch t.kind {
+	case tokWord:
+		name := strings.ToUpper(t.text)
+		_, ok := builtins[name]
+		return ok || aggregates[name] || name == "EXISTS" || name == "NOT" && isKeyword(p.peek(1), "EXISTS")
+	case tokIRI, tokPName:
+		n := p.peek(1)
+		return n.kind == tokPunct && n.text == "("
+	}
+	return false
+}
+
+// parseConstraint parses a bracketted expression or a call.
+func (p *parser) parseConstraint() Node {
+	if p.acceptPunct("(") {
+		e := p.parseExpression()
+		p.expectPunct(")")
+		return e
+	}
+	if !p.isCall() {
+		p.unexpected("\"(\" or function call")
+	}
+	return p.parsePrimaryExpression()
+}
+
+func (p *parser) parseExpression() Node {
+	return p.parseBinary(0)
+}
+
+// binaryOperators is the binary operators by precedence from low to high.
+// The relational operators are not associative.
+var binaryOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"=", "!=", "<", ">", "<=", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+const relationalLevel = 2
+
+func (p *parser) parseBinary(level int) Node {
+	if level == len(binaryOperators) {
+		return p.parseUnaryExpression()
+	}
+	x := p.parseBinary(level + 1)
+	for {
+		if level == relationalLevel && (p.keyword("IN") || p.keyword("NOT") && isKeyword(p.peek(1), "IN")) {
+			return p.parseIn(x)
+		}
+		op, ok := p.binaryOperator(level)
+		if !ok {
+			return x
+		}
+		pos := p.next().pos
+		x = &BinaryExpr{base: base{pos: pos}, Op: op, X: x, Y: p.parseBinary(level + 1)}
+		if level == relationalLevel {
+			return x
+		}
+	}
+}
+
+func (p *parser) binaryOperator(level int) (string, bool) {
+	t := p.tok()
+	if t.kind != tokPunct {
+		return "", false
+	}
+	for _, op := range binaryOperators[level] {
+		if t.text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseIn(x Node) Node {
+	in := &InExpr{base: base{pos: p.tok().pos}, X: x}
+	in.Not = p.acceptKeyword("NOT")
+	p.expectKeyword("IN")
+	in.List = p.parseExpressionList()
+	return in
+}
+
+// parseExpressionList parses `()` or `(expression, ...)`.
+func (p *parser) parseExpressionList() []Node {
+	p.expectPunct("(")
+	var es []Node
+	for !p.acceptPunct(")") {
+		if len(es) > 0 {
+			p.expectPunct(",")
+		}
+		es = append(es, p.parseExpression())
+	}
+	return es
+}
+
+func (p *parser) parseUnaryExpression() Node {
+	t := p.tok()
+	if t.kind == tokPunct && (t.text == "!" || t.text == "+" || t.text == "-") && !p.isSignedNumber() {
+		p.next()
+		return &UnaryExpr{base: base{pos: t.pos}, Op: t.text, X: p.parsePrimaryExpression()}
+	}
+	return p.parsePrimaryExpression()
+}
+
+// nolint: gocyclo
+func (p *parser) parsePrimaryExpression() Node {
+	t := p.tok()
+	switch t.kind {
+	case tokPunct:
+		if p.acceptPunct("(") {
+			e := p.parseExpression()
+			p.expectPunct(")")
+			return e
+		}
+		if p.isSignedNumber() {
+			return p.parseNumericLiteral()
+		}
+	case tokWord:
+		if p.isCall() {
+			return p.parseBuiltinCall()
+		}
+		if isKeyword(t, "true") || isKeyword(t, "false") {
+			return p.parseBooleanLiteral()
+		}
+	case tokIRI, tokPName:
+		iri := p.parseIRI()
+		if !p.punct("(") {
+			return iri
+		}
+		c := &CallExpr{base: base{pos: t.pos}, Function: iri}
+		if p.isNil() {
+			p.next()
+			p.next()
+			return c
+		}
+		p.expectPunct("(")
+		c.Distinct = p.acceptKeyword("DISTINCT")
+		for {
+			c.Args = append(c.Args, p.parseExpression())
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		p.expectPunct(")")
+		return c
+	case tokVar, tokPlaceholder, tokString, tokInteger, tokDecimal, tokDouble:
+		return p.parseVarOrTerm()
+	}
+	p.unexpected("expression")
+	return nil
+}
+
+// nolint: gocyclo
+func (p *parser) parseBuiltinCall() Node {
+	t := p.next()
+	name := strings.ToUpper(t.text)
+	switch {
+	case name == "EXISTS":
+		return &ExistsExpr{base: base{pos: t.pos}, Pattern: p.parseGroupGraphPattern()}
+	case name == "NOT":
+		p.expectKeyword("EXISTS")
+		return &ExistsExpr{base: base{pos: t.pos}, Not: true, Pattern: p.parseGroupGraphPattern()}
+	}
+	c := &CallExpr{base: base{pos: t.pos}, Name: name}
+	if !aggregates[name] {
+		c.Args = p.parseExpressionList()
+		arity := builtins[name]
+		if len(c.Args) < arity[0] || arity[1] >= 0 && len(c.Args) > arity[1] {
+			p.failf(t.pos, "wrong number of arguments to %s: %d", name, len(c.Args))
+		}
+		return c
+	}
+	p.expectPunct("(")
+	c.Distinct = p.acceptKeyword("DISTINCT")
+	if name == "COUNT" && p.acceptPunct("*") {
+		c.Star = true
+	} else {
+		c.Args = []Node{p.parseExpression()}
+	}
+	if name == "GROUP_CONCAT" && p.acceptPunct(";") {
+		p.expectKeyword("SEPARATOR")
+		p.expectPunct("=")
+		s := p.tok()
+		if s.kind != tokString {
+			p.unexpected("string")
+		}
+		p.next()
+		c.Separator = &s.text
+	}
+	p.expectPunct(")")
+	return c
+}
@@ -0,0 +1,449 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pos is a position in the source text.
+type Pos struct {
+	// Offset is the byte offset starting from 0.
+	Offset int
+	// Line is the line number starting from 1.
+	Line int
+	// Column is the column in characters starting from 1.
+	Column int
+}
+
+// String returns the position as "line:column".
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// SyntaxError is an error in the source text.
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at line %d col %d: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokWord is a keyword or `a`.
+	tokWord
+	tokIRI
+	tokPName
+	tokBNode
+	tokVar
+	tokPlaceholder
+	tokString
+	tokLangTag
+	tokInteger
+	tokDecimal
+	tokDouble
+	tokPunct
+)
+
+var tokenNames = map[tokenKind]string{
+	tokEOF:         "end of input",
+	tokWord:        "keyword",
+	tokIRI:         "IRI",
+	tokPName:       "prefixed name",
+	tokBNode:       "blank node",
+	tokVar:         "variable",
+	tokPlaceholder: "placeholder",
+	tokString:      "string",
+	tokLangTag:     "language tag",
+	tokInteger:     "integer",
+	tokDecimal:     "decimal",
+	tokDouble:      "double",
+	tokPunct:       "punctuation",
+}
+
+type token struct {
+	kind tokenKind
+	// text is the token text. It's unescaped for strings and IRIs and has no sigils
+	// for variables, blank nodes and language tags.
+	text string
+	pos  Pos
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return tokenNames[t.kind]
+	case tokString:
+		return strconv.Quote(t.text)
+	case tokIRI:
+		return "<" + t.text + ">"
+	case tokVar:
+		return "?" + t.text
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// lexer splits the source text into tokens.
+type lexer struct {
+	src  string
+	off  int
+	line int
+	// lineStart is the offset of the current line.
+	lineStart int
+	// prev is the kind of the previous token to tell language tags from placeholders.
+	prev tokenKind
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1}
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{
+		Offset: l.off,
+		Line:   l.line,
+		Column: utf8.RuneCountInString(l.src[l.lineStart:l.off]) + 1,
+	}
+}
+
+func (l *lexer) errorf(pos Pos, format string, args ...interface{}) error {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// advance moves the offset forward by n bytes counting lines.
+func (l *lexer) advance(n int) {
+	for i := 0; i < n; i++ {
+		if l.src[l.off] == '\n' {
+			l.line++
+			l.lineStart = l.off + 1
+		}
+		l.off++
+	}
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.off < len(l.src) {
+		switch c := l.src[l.off]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			l.advance(1)
+		case c == '#':
+			for l.off < len(l.src) && l.src[l.off] != '\n' {
+				l.off++
+			}
+		default:
+			return
+		}
+	}
+}
+
+var puncts = []string{"^^", "&&", "||", "!=", "<=", ">=", "{", "}", "(", ")", "[", "]", ".", ",", ";",
+	"*", "/", "+", "-", "!", "=", "<", ">", "^", "|", "?"}
+
+// next returns the next token.
+func (l *lexer) next() (token, error) {
+	l.skipSpaceAndComments()
+	pos := l.pos()
+	if l.off >= len(l.src) {
+		return token{kind: tokEOF, pos: pos}, nil
+	}
+	t, err := l.scan(pos)
+	if err != nil {
+		return token{}, err
+	}
+	l.prev = t.kind
+	return t, nil
+}
+
+// nolint: gocyclo
+func (l *lexer) scan(pos Pos) (token, error) {
+	rest := l.src[l.off:]
+	c := rest[0]
+	switch {
+	case c == '<':
+		if end := iriEnd(rest); end > 0 {
+			l.advance(end + 1)
+			iri, err := unescapeIRI(rest[1:end])
+			if err != nil {
+				return token{}, l.errorf(pos, "%v", err)
+			}
+			return token{kind: tokIRI, text: iri, pos: pos}, nil
+		}
+	case c == '"' || c == '\'':
+		return l.scanString(pos)
+	case c == '?' || c == '$':
+		if n := varNameLen(rest[1:]); n > 0 {
+			l.advance(1 + n)
+			// `$n` is an ordinal placeholder as `scanPlaceholders` of the client finds.
+			if c == '$' && isDigits(rest[1:1+n]) {
+				return token{kind: tokPlaceholder, text: rest[:1+n], pos: pos}, nil
+			}
+			return token{kind: tokVar, text: rest[1 : 1+n], pos: pos}, nil
+		}
+		if c == '$' {
+			return token{}, l.errorf(pos, "variable name expected after $")
+		}
+	case c == '@':
+		if l.prev == tokString {
+			n := langTagLen(rest[1:])
+			if n == 0 {
+				return token{}, l.errorf(pos, "language tag expected")
+			}
+			l.advance(1 + n)
+			return token{kind: tokLangTag, text: rest[1 : 1+n], pos: pos}, nil
+		}
+		if n := varNameLen(rest[1:]); n > 0 {
+			l.advance(1 + n)
+			return token{kind: tokPlaceholder, text: rest[:1+n], pos: pos}, nil
+		}
+		return token{}, l.errorf(pos, "placeholder name expected after @")
+	case c == '_' && strings.HasPrefix(rest, "_:"):
+		n := nameLen(rest[2:])
+		if n == 0 {
+			return token{}, l.errorf(pos, "blank node label expected")
+		}
+		l.advance(2 + n)
+		return token{kind: tokBNode, text: rest[2 : 2+n], pos: pos}, nil
+	case isDigit(c) || c == '.' && len(rest) > 1 && isDigit(rest[1]):
+		return l.scanNumber(pos), nil
+	case isNameStart(c) || c == ':':
+		n := nameLen(rest)
+		word := rest[:n]
+		l.advance(n)
+		if strings.Contains(word, ":") {
+			return token{kind: tokPName, text: word, pos: pos}, nil
+		}
+		return token{kind: tokWord, text: word, pos: pos}, nil
+	}
+	for _, p := range puncts {
+		if strings.HasPrefix(rest, p) {
+			l.advance(len(p))
+			return token{kind: tokPunct, text: p, pos: pos}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return token{}, l.errorf(pos, "unexpected character %q", r)
+}
+
+// iriEnd returns the index of `>` closing the IRIREF or 0 if it's not an IRIREF.
+func iriEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '>':
+			return i
+		case c <= ' ' || strings.IndexByte("<\"{}|^`", c) >= 0:
+			return 0
+		}
+	}
+	return 0
+}
+
+// unescapeIRI decodes UCHAR escapes in an IRIREF.
+func unescapeIRI(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		r, n, err := unescapeUChar(s[i:])
+		if err != nil {
+			return "", err
+		}
+		b.WriteRune(r)
+		i += n
+	}
+	return b.String(), nil
+}
+
+func unescapeUChar(s string) (rune, int, error) {
+	digits := 0
+	switch {
+	case strings.HasPrefix(s, `\u`):
+		digits = 4
+	case strings.HasPrefix(s, `\U`):
+		digits = 8
+	default:
+		return 0, 0, fmt.Errorf("invalid escape sequence")
+	}
+	if len(s) < 2+digits {
+		return 0, 0, fmt.Errorf("invalid escape sequence %q", s)
+	}
+	n, err := strconv.ParseUint(s[2:2+digits], 16, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid escape sequence %q", s[:2+digits])
+	}
+	return rune(n), 2 + digits, nil
+}
+
+// scanString scans a short or long string literal.
+// nolint: gocyclo
+func (l *lexer) scanString(pos Pos) (token, error) {
+	rest := l.src[l.off:]
+	delim := rest[:1]
+	if strings.HasPrefix(rest, strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	var b strings.Builder
+	i := len(delim)
+	for {
+		if i >= len(rest) {
+			return token{}, l.errorf(pos, "unterminated string")
+		}
+		if strings.HasPrefix(rest[i:], delim) {
+			break
+		}
+		c := rest[i]
+		switch {
+		case c == '\\':
+			r, n, err := unescapeString(rest[i:])
+			if err != nil {
+				l.advance(i)
+				return token{}, l.errorf(l.pos(), "%v", err)
+			}
+			b.WriteRune(r)
+			i += n
+		case len(delim) == 1 && (c == '\n' || c == '\r'):
+			return token{}, l.errorf(pos, "unterminated string")
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	l.advance(i + len(delim))
+	return token{kind: tokString, text: b.String(), pos: pos}, nil
+}
+
+func unescapeString(s string) (rune, int, error) {
+	if len(s) < 2 {
+		return 0, 0, fmt.Errorf("invalid escape sequence")
+	}
+	switch s[1] {
+	case 't':
+		return '\t', 2, nil
+	case 'b':
+		return '\b', 2, nil
+	case 'n':
+		return '\n', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case 'f':
+		return '\f', 2, nil
+	case '"', '\'', '\\':
+		return rune(s[1]), 2, nil
+	default:
+		return unescapeUChar(s)
+	}
+}
+
+func (l *lexer) scanNumber(pos Pos) token {
+	rest := l.src[l.off:]
+	i := 0
+	for i < len(rest) && isDigit(rest[i]) {
+		i++
+	}
+	kind := tokInteger
+	if i < len(rest)-1 && rest[i] == '.' && isDigit(rest[i+1]) {
+		kind = tokDecimal
+		i++
+		for i < len(rest) && isDigit(rest[i]) {
+			i++
+		}
+	}
+	if i < len(rest) && (rest[i] == 'e' || rest[i] == 'E') {
+		j := i + 1
+		if j < len(rest) && (rest[j] == '+' || rest[j] == '-') {
+			j++
+		}
+		if j < len(rest) && isDigit(rest[j]) {
+			for j < len(rest) && isDigit(rest[j]) {
+				j++
+			}
+			kind, i = tokDouble, j
+		}
+	}
+	l.advance(i)
+	return token{kind: kind, text: rest[:i], pos: pos}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isNameStart(c byte) bool {
+	return isLetter(c) || c == '_' || c >= 0x80
+}
+
+// varNameLen returns the length of the VARNAME at the head of s.
+func varNameLen(s string) int {
+	i := 0
+	for i < len(s) && (isLetter(s[i]) || isDigit(s[i]) || s[i] == '_' || s[i] >= 0x80) {
+		i++
+	}
+	return i
+}
+
+// langTagLen returns the length of the language tag at the head of s.
+func langTagLen(s string) int {
+	i := 0
+	for i < len(s) && isLetter(s[i]) {
+		i++
+	}
+	if i == 0 {
+		return 0
+	}
+	for i < len(s)-1 && s[i] == '-' && (isLetter(s[i+1]) || isDigit(s[i+1])) {
+		i++
+		for i < len(s) && (isLetter(s[i]) || isDigit(s[i])) {
+			i++
+		}
+	}
+	return i
+}
+
+// nameLen returns the length of the keyword, prefixed name or blank node label at
+// the head of s. Names don't end with `.` unless it's escaped.
+func nameLen(s string) int {
+	i, end := 0, 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '.':
+			i++
+			continue
+		case isLetter(c) || isDigit(c) || c == '_' || c == '-' || c == ':' || c >= 0x80:
+			i++
+		case c == '%' && i+2 < len(s):
+			i += 3
+		case c == '\\' && i+1 < len(s):
+			i += 2
+		default:
+			return end
+		}
+		end = i
+	}
+	return end
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// nolint: scopelint
+func Test_lexer_next(t *testing.T) {
+	type tok struct {
+		kind tokenKind
+		text string
+	}
+	tests := []struct {
+		name string
+		src  string
+		want []tok
+	}{
+		{
+			name: "terms",
+			src:  `<http://example.com/a> ex:b _:c ?d $e $1 @name a`,
+			want: []tok{
+				{tokIRI, "http://example.com/a"}, {tokPName, "ex:b"}, {tokBNode, "c"}, {tokVar, "d"}, {tokVar, "e"},
+				{tokPlaceholder, "$1"}, {tokPlaceholder, "@name"}, {tokWord, "a"},
+			},
+		},
+		{
+			name: "strings",
+			src:  `"a\"b" 'c' """d"e""" '''f''' "g"@en-US "A"`,
+			want: []tok{
+				{tokString, `a"b`}, {tokString, "c"}, {tokString, `d"e`}, {tokString, "f"},
+				{tokString, "g"}, {tokLangTag, "en-US"}, {tokString, "A"},
+			},
+		},
+		{
+			name: "numbers",
+			src:  `1 2.5 .5 1e10 1.5E-3 3.`,
+			want: []tok{
+				{tokInteger, "1"}, {tokDecimal, "2.5"}, {tokDecimal, ".5"}, {tokDouble, "1e10"},
+				{tokDouble, "1.5E-3"}, {tokInteger, "3"}, {tokPunct, "."},
+			},
+		},
+		{
+			name: "operators",
+			src:  `?a < ?b && ?c != "x"^^xsd:string || !?d`,
+			want: []tok{
+				{tokVar, "a"}, {tokPunct, "<"}, {tokVar, "b"}, {tokPunct, "&&"}, {tokVar, "c"},
+				{tokPunct, "!="}, {tokString, "x"}, {tokPunct, "^^"}, {tokPName, "xsd:string"},
+				{tokPunct, "||"}, {tokPunct, "!"}, {tokVar, "d"},
+			},
+		},
+		{
+			name: "prefixed names",
+			src:  `: ex: ex:a.b ex:c. :d%20\.`,
+			want: []tok{
+				{tokPName, ":"}, {tokPName, "ex:"}, {tokPName, "ex:a.b"}, {tokPName, "ex:c"},
+				{tokPunct, "."}, {tokPName, `:d%20\.`},
+			},
+		},
+		{
+			name: "comments",
+			src:  "SELECT # <comment>\n* {}",
+			want: []tok{{tokWord, "SELECT"}, {tokPunct, "*"}, {tokPunct, "{"}, {tokPunct, "}"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLexer(tt.src)
+			var got []tok
+			for {
+				token, err := l.next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if token.kind == tokEOF {
+					break
+				}
+				got = append(got, tok{token.kind, token.text})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lexer.next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_lexer_pos(t *testing.T) {
+	l := newLexer("ASK\n  { \"é\" ?x }")
+	var got []Pos
+	for {
+		token, err := l.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, token.pos)
+		if token.kind == tokEOF {
+			break
+		}
+	}
+	want := []Pos{
+		{Offset: 0, Line: 1, Column: 1},
+		{Offset: 6, Line: 2, Column: 3},
+		{Offset: 8, Line: 2, Column: 5},
+		{Offset: 13, Line: 2, Column: 9},
+		{Offset: 16, Line: 2, Column: 12},
+		{Offset: 17, Line: 2, Column: 13},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+}
+
+// nolint: scopelint
+func Test_lexer_error(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "unterminated string", src: `ASK { "a }`, want: "syntax error at line 1 col 7: unterminated string"},
+		{name: "newline in string", src: "ASK { 'a\n' }", want: "syntax error at line 1 col 7: unterminated string"},
+		{name: "bad escape", src: `"a\q"`, want: "syntax error at line 1 col 3: invalid escape sequence"},
+		{name: "no variable name", src: `$ `, want: "syntax error at line 1 col 1: variable name expected after $"},
+		{name: "no language tag", src: `"a"@1`, want: "syntax error at line 1 col 4: language tag expected"},
+		{name: "unexpected character", src: "ASK\n~", want: "syntax error at line 2 col 1: unexpected character '~'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLexer(tt.src)
+			for {
+				token, err := l.next()
+				if err != nil {
+					if err.Error() != tt.want {
+						t.Errorf("lexer.next() error = %v, want %v", err, tt.want)
+					}
+					return
+				}
+				if token.kind == tokEOF {
+					t.Fatal("no error")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// IsBlankNodeLabel reports whether s is a BLANK_NODE_LABEL without `_:`.
+func IsBlankNodeLabel(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	if !isPNCharsU(r) && !(r >= '0' && r <= '9') {
+		return false
+	}
+	return isNameRest(s[n:], false)
+}
+
+// IsVarName reports whether s is a VARNAME without `?` or `$`.
+func IsVarName(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case isPNCharsU(r), r >= '0' && r <= '9':
+		case i > 0 && (r == 0x00B7 || r >= 0x0300 && r <= 0x036F || r >= 0x203F && r <= 0x2040):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsPrefix reports whether s is a PN_PREFIX or empty, which is the name of a PREFIX
+// declaration without `:`.
+func IsPrefix(s string) bool {
+	return utf8.ValidString(s) && isPrefix(s)
+}
+
+// IsLanguageTag reports whether s is a LANGTAG without `@`.
+func IsLanguageTag(s string) bool {
+	return s != "" && langTagLen(s) == len(s)
+}
+
+// IsPrefixedName reports whether s is a PNAME_NS such as `foaf:` or a PNAME_LN
+// such as `foaf:name`.
+func IsPrefixedName(s string) bool {
+	i := strings.IndexByte(s, ':')
+	if i < 0 || !utf8.ValidString(s) {
+		return false
+	}
+	return isPrefix(s[:i]) && isLocal(s[i+1:])
+}
+
+// isPrefix reports whether s is a PN_PREFIX or empty.
+func isPrefix(s string) bool {
+	if s == "" {
+		return true
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	if !isPNCharsBase(r) {
+		return false
+	}
+	return isNameRest(s[n:], false)
+}
+
+// isLocal reports whether s is a PN_LOCAL or empty.
+func isLocal(s string) bool {
+	if s == "" {
+		return true
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	switch {
+	case r == '%' || r == '\\':
+		n = plxLen(s)
+		if n == 0 {
+			return false
+		}
+	case !isPNCharsU(r) && r != ':' && !(r >= '0' && r <= '9'):
+		return false
+	}
+	return isNameRest(s[n:], true)
+}
+
+// isNameRest reports whether s is `((PN_CHARS | '.')* PN_CHARS)?`. local allows
+// `:` and PLX of PN_LOCAL.
+func isNameRest(s string, local bool) bool {
+	last := rune(0)
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case local && (r == '%' || r == '\\'):
+			n = plxLen(s[i:])
+			if n == 0 {
+				return false
+			}
+		case isPNChars(r) || r == '.' || local && r == ':':
+		default:
+			return false
+		}
+		last = r
+		i += n
+	}
+	return last != '.'
+}
+
+// plxLen returns the length of the PLX at the head of s or 0.
+func plxLen(s string) int {
+	switch {
+	case len(s) >= 3 && s[0] == '%' && isHex(s[1]) && isHex(s[2]):
+		return 3
+	case len(s) >= 2 && s[0] == '\\' && strings.IndexByte("_~.-!$&'()*+,;=/?#@%", s[1]) >= 0:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// nolint: gocyclo
+func isPNCharsBase(r rune) bool {
+	switch {
+	case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		return true
+	case r >= 0x00C0 && r <= 0x00D6, r >= 0x00D8 && r <= 0x00F6, r >= 0x00F8 && r <= 0x02FF:
+		return true
+	case r >= 0x0370 && r <= 0x037D, r >= 0x037F && r <= 0x1FFF, r >= 0x200C && r <= 0x200D:
+		return true
+	case r >= 0x2070 && r <= 0x218F, r >= 0x2C00 && r <= 0x2FEF, r >= 0x3001 && r <= 0xD7FF:
+		return true
+	case r >= 0xF900 && r <= 0xFDCF, r >= 0xFDF0 && r <= 0xFFFD, r >= 0x10000 && r <= 0xEFFFF:
+		return true
+	default:
+		return false
+	}
+}
+
+func isPNCharsU(r rune) bool {
+	return isPNCharsBase(r) || r == '_'
+}
+
+func isPNChars(r rune) bool {
+	return isPNCharsU(r) || r == '-' || r >= '0' && r <= '9' || r == 0x00B7 ||
+		r >= 0x0300 && r <= 0x036F || r >= 0x203F && r <= 0x2040
+}
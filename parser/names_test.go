@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"testing"
+)
+
+// nolint: scopelint
+func TestIsBlankNodeLabel(t *testing.T) {
+	tests := map[string]bool{
+		"b0":                     true,
+		"0b":                     true,
+		"_x":                     true,
+		"a.b-c":                  true,
+		"é·":                     true,
+		"":                       false,
+		"-a":                     false,
+		"a.":                     false,
+		"a:b":                    false,
+		"a%20":                   false,
+		"x . } ; DROP ALL #":     false,
+		"x\n":                    false,
+		"genid-genid1":           true,
+		"\xff":                   false,
+		"a\u0300":                true,
+		"\u0300a":                false,
+		"a b":                    false,
+		"a}":                     false,
+		"a\\.":                   false,
+		"abcdefghijklmnopqrstuv": true,
+	}
+	for s, want := range tests {
+		t.Run(s, func(t *testing.T) {
+			if got := IsBlankNodeLabel(s); got != want {
+				t.Errorf("IsBlankNodeLabel(%q) = %v, want %v", s, got, want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestIsVarName(t *testing.T) {
+	tests := map[string]bool{
+		"x":                      true,
+		"_x1":                    true,
+		"1x":                     true,
+		"a\u0300":                true,
+		"":                       false,
+		"\u0300a":                false,
+		"a-b":                    false,
+		"a.b":                    false,
+		"x } ; DROP ALL ; #":     false,
+		"\xff":                   false,
+		"abcdefghijklmnopqrstuv": true,
+	}
+	for s, want := range tests {
+		t.Run(s, func(t *testing.T) {
+			if got := IsVarName(s); got != want {
+				t.Errorf("IsVarName(%q) = %v, want %v", s, got, want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestIsPrefix(t *testing.T) {
+	tests := map[string]bool{
+		"":        true,
+		"ex":      true,
+		"foaf":    true,
+		"a.b-c":   true,
+		"ex x":    false,
+		"ex:":     false,
+		"1x":      false,
+		"_x":      false,
+		"ex.":     false,
+		"\xff":    false,
+		"ex> . }": false,
+	}
+	for s, want := range tests {
+		t.Run(s, func(t *testing.T) {
+			if got := IsPrefix(s); got != want {
+				t.Errorf("IsPrefix(%q) = %v, want %v", s, got, want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestIsLanguageTag(t *testing.T) {
+	tests := map[string]bool{
+		"en":              true,
+		"en-US":           true,
+		"de-CH-1996":      true,
+		"":                false,
+		"1en":             false,
+		"en-":             false,
+		"en US":           false,
+		"en . } ; DROP #": false,
+	}
+	for s, want := range tests {
+		t.Run(s, func(t *testing.T) {
+			if got := IsLanguageTag(s); got != want {
+				t.Errorf("IsLanguageTag(%q) = %v, want %v", s, got, want)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestIsPrefixedName(t *testing.T) {
+	tests := map[string]bool{
+		"foaf:name":        true,
+		"foaf:":            true,
+		":":                true,
+		":a":               true,
+		"xsd:integer":      true,
+		"ex:a.b":           true,
+		"ex:1":             true,
+		"ex:a:b":           true,
+		`ex:a\.`:           true,
+		"ex:a%20b":         true,
+		"a":                false,
+		"ex:a.":            false,
+		"1x:a":             false,
+		"ex.:a":            false,
+		"ex:a%2":           false,
+		`ex:a\z`:           false,
+		"ex:a b":           false,
+		"ex:a> . } ; DROP": false,
+	}
+	for s, want := range tests {
+		t.Run(s, func(t *testing.T) {
+			if got := IsPrefixedName(s); got != want {
+				t.Errorf("IsPrefixedName(%q) = %v, want %v", s, got, want)
+			}
+		})
+	}
+}
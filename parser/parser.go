@@ -0,0 +1,418 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+	rdfType      = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+)
+
+// Parse parses the query or the update request. It returns `*Query` or `*Update`.
+// Prefixed names are not resolved, so the prefixes may be declared elsewhere.
+// Placeholders `$n` and `@name` are accepted in the places of terms. `$n` is not a variable.
+func Parse(src string) (Node, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	var n Node
+	err = p.run(func() {
+		var pro Prologue
+		p.parsePrologue(&pro)
+		if queryForm(p.tok()) != FormUnknown {
+			n = p.parseQuery(pro)
+			return
+		}
+		n = p.parseUpdate(pro)
+	})
+	return n, err
+}
+
+// ParseQuery parses the query.
+func ParseQuery(src string) (*Query, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	var q *Query
+	err = p.run(func() {
+		var pro Prologue
+		p.parsePrologue(&pro)
+		q = p.parseQuery(pro)
+	})
+	return q, err
+}
+
+// ParseUpdate parses the update request.
+func ParseUpdate(src string) (*Update, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	var u *Update
+	err = p.run(func() {
+		var pro Prologue
+		p.parsePrologue(&pro)
+		u = p.parseUpdate(pro)
+	})
+	return u, err
+}
+
+// DetectForm returns the form from the keyword following the prologue without
+// parsing the rest. Text with only the prologue is an empty update request.
+func DetectForm(src string) (Form, error) {
+	l := newLexer(src)
+	for {
+		t, err := l.next()
+		if err != nil {
+			return FormUnknown, err
+		}
+		if t.kind == tokEOF {
+			return FormUpdate, nil
+		}
+		switch {
+		case isKeyword(t, "BASE"):
+			if _, err := l.next(); err != nil {
+				return FormUnknown, err
+			}
+			continue
+		case isKeyword(t, "PREFIX"):
+			for i := 0; i < 2; i++ {
+				if _, err := l.next(); err != nil {
+					return FormUnknown, err
+				}
+			}
+			continue
+		}
+		if f := queryForm(t); f != FormUnknown {
+			return f, nil
+		}
+		if t.kind == tokWord && updateKeywords[strings.ToUpper(t.text)] {
+			return FormUpdate, nil
+		}
+		return FormUnknown, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s, expected query or update", t)}
+	}
+}
+
+var updateKeywords = map[string]bool{
+	"LOAD": true, "CLEAR": true, "DROP": true, "CREATE": true, "ADD": true, "MOVE": true,
+	"COPY": true, "INSERT": true, "DELETE": true, "WITH": true,
+}
+
+func queryForm(t token) Form {
+	switch {
+	case isKeyword(t, "SELECT"):
+		return FormSelect
+	case isKeyword(t, "ASK"):
+		return FormAsk
+	case isKeyword(t, "CONSTRUCT"):
+		return FormConstruct
+	case isKeyword(t, "DESCRIBE"):
+		return FormDescribe
+	default:
+		return FormUnknown
+	}
+}
+
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, keyword)
+}
+
+// parser is a recursive descent parser. Parse methods panic with `*SyntaxError`
+// and `run` recovers it.
+type parser struct {
+	toks []token
+	i    int
+}
+
+func newParser(src string) (*parser, error) {
+	l := newLexer(src)
+	var toks []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		toks = append(toks, t)
+		if t.kind == tokEOF {
+			return &parser{toks: toks}, nil
+		}
+	}
+}
+
+func (p *parser) run(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	f()
+	return nil
+}
+
+func (p *parser) tok() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) peek(n int) token {
+	if p.i+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.i+n]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) failf(pos Pos, format string, args ...interface{}) {
+	panic(&SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) unexpected(expected string) {
+	p.failf(p.tok().pos, "unexpected %s, expected %s", p.tok(), expected)
+}
+
+func (p *parser) keyword(keyword string) bool {
+	return isKeyword(p.tok(), keyword)
+}
+
+func (p *parser) punct(s string) bool {
+	t := p.tok()
+	return t.kind == tokPunct && t.text == s
+}
+
+func (p *parser) expectKeyword(keyword string) token {
+	if !p.keyword(keyword) {
+		p.unexpected(keyword)
+	}
+	return p.next()
+}
+
+func (p *parser) expectPunct(s string) token {
+	if !p.punct(s) {
+		p.unexpected(strconv.Quote(s))
+	}
+	return p.next()
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	if p.keyword(keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptPunct(s string) bool {
+	if p.punct(s) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectEOF() {
+	if p.tok().kind != tokEOF {
+		p.unexpected(tokenNames[tokEOF])
+	}
+}
+
+// isNil reports whether the current tokens are `()`.
+func (p *parser) isNil() bool {
+	n := p.peek(1)
+	return p.punct("(") && n.kind == tokPunct && n.text == ")"
+}
+
+// isAnon reports whether the current tokens are `[]`.
+func (p *parser) isAnon() bool {
+	n := p.peek(1)
+	return p.punct("[") && n.kind == tokPunct && n.text == "]"
+}
+
+func (p *parser) parsePrologue(pro *Prologue) {
+	for {
+		switch {
+		case p.keyword("BASE"):
+			p.next()
+			if p.tok().kind != tokIRI {
+				p.unexpected("IRI")
+			}
+			pro.Base = p.next().text
+		case p.keyword("PREFIX"):
+			pos := p.next().pos
+			t := p.tok()
+			if t.kind != tokPName || strings.Index(t.text, ":") != len(t.text)-1 {
+				p.unexpected("prefix")
+			}
+			p.next()
+			if p.tok().kind != tokIRI {
+				p.unexpected("IRI")
+			}
+			pro.Prefixes = append(pro.Prefixes, &PrefixDecl{
+				base:   base{pos: pos},
+				Prefix: strings.TrimSuffix(t.text, ":"),
+				IRI:    p.next().text,
+			})
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) parseVar() *Var {
+	t := p.tok()
+	if t.kind != tokVar {
+		p.unexpected("variable")
+	}
+	p.next()
+	return &Var{base: base{pos: t.pos}, Name: t.text}
+}
+
+func (p *parser) parseIRI() Node {
+	t := p.tok()
+	switch t.kind {
+	case tokIRI:
+		p.next()
+		return &IRI{base: base{pos: t.pos}, Value: t.text}
+	case tokPName:
+		p.next()
+		i := strings.Index(t.text, ":")
+		return &PrefixedName{base: base{pos: t.pos}, Prefix: t.text[:i], Local: t.text[i+1:]}
+	case tokPlaceholder:
+		return p.parsePlaceholder()
+	}
+	p.unexpected("IRI")
+	return nil
+}
+
+func (p *parser) parsePlaceholder() Node {
+	t := p.next()
+	return &Placeholder{base: base{pos: t.pos}, Name: t.text}
+}
+
+// parseVarOrIRI parses a variable, an IRI or a placeholder.
+func (p *parser) parseVarOrIRI() Node {
+	if p.tok().kind == tokVar {
+		return p.parseVar()
+	}
+	return p.parseIRI()
+}
+
+func (p *parser) isVarOrIRI() bool {
+	k := p.tok().kind
+	return k == tokVar || k == tokIRI || k == tokPName || k == tokPlaceholder
+}
+
+// parseVarOrTerm parses a variable, a placeholder or an RDF term.
+// nolint: gocyclo
+func (p *parser) parseVarOrTerm() Node {
+	t := p.tok()
+	switch t.kind {
+	case tokVar:
+		return p.parseVar()
+	case tokPlaceholder:
+		return p.parsePlaceholder()
+	case tokIRI, tokPName:
+		return p.parseIRI()
+	case tokBNode:
+		p.next()
+		return &BlankNode{base: base{pos: t.pos}, Label: t.text}
+	case tokString:
+		return p.parseRDFLiteral()
+	case tokInteger, tokDecimal, tokDouble:
+		return p.parseNumericLiteral()
+	case tokWord:
+		if isKeyword(t, "true") || isKeyword(t, "false") {
+			return p.parseBooleanLiteral()
+		}
+	case tokPunct:
+		switch {
+		case p.isNil():
+			p.next()
+			p.next()
+			return &Nil{base: base{pos: t.pos}}
+		case p.isAnon():
+			p.next()
+			p.next()
+			return &BlankNode{base: base{pos: t.pos}}
+		case p.isSignedNumber():
+			return p.parseNumericLiteral()
+		}
+	}
+	p.unexpected("term")
+	return nil
+}
+
+func (p *parser) parseRDFLiteral() Node {
+	t := p.next()
+	l := &Literal{base: base{pos: t.pos}, Value: t.text}
+	switch {
+	case p.tok().kind == tokLangTag:
+		l.Lang = p.next().text
+	case p.acceptPunct("^^"):
+		l.Datatype = p.parseIRI()
+	}
+	return l
+}
+
+// isSignedNumber reports whether the current tokens are a sign and a number
+// without spaces between them.
+func (p *parser) isSignedNumber() bool {
+	if !p.punct("+") && !p.punct("-") {
+		return false
+	}
+	n := p.peek(1)
+	switch n.kind {
+	case tokInteger, tokDecimal, tokDouble:
+		return n.pos.Offset == p.tok().pos.Offset+1
+	}
+	return false
+}
+
+func (p *parser) parseNumericLiteral() Node {
+	pos := p.tok().pos
+	sign := ""
+	if p.isSignedNumber() {
+		sign = p.next().text
+	}
+	t := p.tok()
+	var dataType string
+	switch t.kind {
+	case tokInteger:
+		dataType = "integer"
+	case tokDecimal:
+		dataType = "decimal"
+	case tokDouble:
+		dataType = "double"
+	default:
+		p.unexpected("number")
+	}
+	p.next()
+	return &Literal{
+		base:     base{pos: pos},
+		Value:    sign + t.text,
+		Datatype: &IRI{base: base{pos: t.pos}, Value: xsdNamespace + dataType},
+	}
+}
+
+func (p *parser) parseBooleanLiteral() Node {
+	t := p.next()
+	return &Literal{
+		base:     base{pos: t.pos},
+		Value:    strings.ToLower(t.text),
+		Datatype: &IRI{base: base{pos: t.pos}, Value: xsdNamespace + "boolean"},
+	}
+}
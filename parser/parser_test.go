@@ -0,0 +1,282 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func at(offset, line, column int) base {
+	return base{pos: Pos{Offset: offset, Line: line, Column: column}}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("PREFIX ex: <http://example.com/>\nSELECT ?s WHERE { ?s a ex:C }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Var{base: at(40, 2, 8), Name: "s"}
+	want := &Query{
+		base: at(33, 2, 1),
+		Prologue: Prologue{Prefixes: []*PrefixDecl{
+			{base: at(0, 1, 1), Prefix: "ex", IRI: "http://example.com/"},
+		}},
+		Form:       FormSelect,
+		Projection: []*Projection{{base: s.base, Var: s}},
+		Where: &GroupPattern{base: at(49, 2, 17), Elements: []Node{
+			&Triples{
+				base:    at(51, 2, 19),
+				Subject: &Var{base: at(51, 2, 19), Name: "s"},
+				Properties: []*Property{{
+					base:    at(54, 2, 22),
+					Verb:    &IRI{base: at(54, 2, 22), Value: rdfType},
+					Objects: []Node{&PrefixedName{base: at(56, 2, 24), Prefix: "ex", Local: "C"}},
+				}},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+// nolint: scopelint
+func TestParse_valid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		form Form
+	}{
+		{
+			name: "select",
+			src: `PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+SELECT DISTINCT ?name (COUNT(DISTINCT ?friend) AS ?n)
+FROM <http://example.com/g>
+FROM NAMED <http://example.com/h>
+WHERE {
+  ?x foaf:name ?name ; foaf:knows ?friend , [ a foaf:Person ] .
+  OPTIONAL { ?x foaf:age ?age FILTER (?age >= 18 && ?age < 65) }
+  { ?x a foaf:Person } UNION { ?x a foaf:Agent }
+  MINUS { ?x foaf:blocked true }
+  GRAPH ?g { ?x foaf:mbox ?mbox }
+  SERVICE SILENT <http://example.com/sparql> { ?x foaf:nick ?nick }
+  BIND (CONCAT("Hi, "@en, STR(?name)) AS ?greeting)
+  VALUES (?x ?y) { (<http://example.com/a> UNDEF) (foaf:b "b"^^foaf:c) }
+  FILTER NOT EXISTS { ?x foaf:knows/foaf:knows+ ?x }
+  FILTER (?name IN ("a", 'b') && ?age NOT IN (-1, +2.5, 1e3))
+  FILTER REGEX(?name, "^a", "i")
+  FILTER foaf:check(DISTINCT ?x, ?name)
+}
+GROUP BY ?name (LCASE(?name) AS ?lower) STR(?name)
+HAVING (COUNT(?friend) > 1) (SUM(?age) < 100)
+ORDER BY DESC(?n) ?name ASC(?age) STRLEN(?name)
+OFFSET 10 LIMIT 5
+VALUES ?name { "a" "b" }`,
+			form: FormSelect,
+		},
+		{
+			name: "select all with placeholders",
+			src:  `SELECT * { ?s ?p $1 , @name . }`,
+			form: FormSelect,
+		},
+		{
+			name: "subquery",
+			src:  `SELECT ?s { { SELECT ?s (MAX(?o) AS ?m) { ?s ?p ?o } GROUP BY ?s LIMIT 1 } }`,
+			form: FormSelect,
+		},
+		{
+			name: "aggregates",
+			src:  `SELECT (COUNT(*) AS ?c) (GROUP_CONCAT(?o; SEPARATOR=", ") AS ?g) (SAMPLE(?o) AS ?s) { ?s ?p ?o }`,
+			form: FormSelect,
+		},
+		{
+			name: "property paths",
+			src:  `ASK { ?s ^<p>/(<q>|<r>)* ?o . ?s !(a|^<p>) ?o . ?s !<p> ?o . ?s <p>? ?o }`,
+			form: FormAsk,
+		},
+		{
+			name: "collections and blank nodes",
+			src:  `ASK { (1 ?x [ <p> () ]) <q> [] . [ <p> <o> ] . _:b <p> _:c }`,
+			form: FormAsk,
+		},
+		{
+			name: "construct",
+			src:  `BASE <http://example.com/> CONSTRUCT { ?s <p> ?o . ?o <q> "x" } WHERE { ?s <r> ?o } ORDER BY ?s LIMIT 1`,
+			form: FormConstruct,
+		},
+		{
+			name: "construct where",
+			src:  `CONSTRUCT WHERE { ?s ?p ?o }`,
+			form: FormConstruct,
+		},
+		{
+			name: "describe",
+			src:  `describe <http://example.com/a> ?x where { ?x <p> ?y }`,
+			form: FormDescribe,
+		},
+		{
+			name: "describe all without where",
+			src:  `DESCRIBE *`,
+			form: FormDescribe,
+		},
+		{
+			name: "arithmetic",
+			src:  `SELECT (?a + ?b * -?c / 2 - 1 AS ?x) (?a -1 AS ?y) (!BOUND(?a) AS ?z) {}`,
+			form: FormSelect,
+		},
+		{
+			name: "update",
+			src:  `PREFIX ex: <http://example.com/> INSERT DATA { ex:a ex:b ex:c }`,
+			form: FormUpdate,
+		},
+		{
+			name: "empty update",
+			src:  `PREFIX ex: <http://example.com/>`,
+			form: FormUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var form Form
+			switch n := got.(type) {
+			case *Query:
+				form = n.Form
+			case *Update:
+				form = FormUpdate
+			}
+			if form != tt.form {
+				t.Errorf("form = %v, want %v", form, tt.form)
+			}
+		})
+	}
+}
+
+// nolint: scopelint
+func TestParse_error(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "typo",
+			src:  "SELECT ?s\nWHERE { ?s ?p ?o }\nLIMT 1",
+			want: `syntax error at line 3 col 1: unexpected "LIMT", expected end of input`,
+		},
+		{
+			name: "missing object",
+			src:  "ASK { ?s ?p }",
+			want: `syntax error at line 1 col 13: unexpected "}", expected term`,
+		},
+		{
+			name: "missing dot",
+			src:  "ASK { ?s ?p ?o ?a ?b ?c }",
+			want: `syntax error at line 1 col 16: unexpected ?a, expected "." or "}"`,
+		},
+		{
+			name: "unclosed group",
+			src:  "ASK { ?s ?p ?o",
+			want: `syntax error at line 1 col 15: unexpected end of input, expected "." or "}"`,
+		},
+		{
+			name: "empty projection",
+			src:  "SELECT WHERE {}",
+			want: `syntax error at line 1 col 8: unexpected "WHERE", expected variable, "(" or "*"`,
+		},
+		{
+			name: "projection without AS",
+			src:  "SELECT (?x + 1) {}",
+			want: `syntax error at line 1 col 15: unexpected ")", expected AS`,
+		},
+		{
+			name: "wrong number of arguments",
+			src:  "ASK { FILTER (STRLEN(?a, ?b)) }",
+			want: `syntax error at line 1 col 15: wrong number of arguments to STRLEN: 2`,
+		},
+		{
+			name: "values length",
+			src:  "ASK { VALUES (?a ?b) { (1) } }",
+			want: `syntax error at line 1 col 24: 1 values for 2 variables`,
+		},
+		{
+			name: "variable in values",
+			src:  "ASK { VALUES ?a { ?b } }",
+			want: `syntax error at line 1 col 19: unexpected ?b, expected IRI, literal or UNDEF`,
+		},
+		{
+			name: "bad prefix",
+			src:  "PREFIX ex:a <http://example.com/> ASK {}",
+			want: `syntax error at line 1 col 8: unexpected "ex:a", expected prefix`,
+		},
+		{
+			name: "not a query",
+			src:  "HELLO",
+			want: `syntax error at line 1 col 1: unexpected "HELLO", expected update operation`,
+		},
+		{
+			name: "lexer error",
+			src:  "ASK { ?s ?p 'o }",
+			want: `syntax error at line 1 col 13: unterminated string`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.src)
+			if err == nil {
+				t.Fatalf("Parse() = %+v, want error", got)
+			}
+			if _, ok := err.(*SyntaxError); !ok {
+				t.Errorf("Parse() error = %T, want *SyntaxError", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Parse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	if _, err := ParseQuery("CLEAR ALL"); err == nil {
+		t.Error("ParseQuery() of an update must fail")
+	}
+	q, err := ParseQuery("SELECT * {} LIMIT 10 OFFSET 20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.All || *q.Limit != 10 || *q.Offset != 20 {
+		t.Errorf("ParseQuery() = %+v", q)
+	}
+}
+
+// nolint: scopelint
+func TestDetectForm(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    Form
+		wantErr bool
+	}{
+		{name: "select", src: "PREFIX ex: <http://example.com/>\nselect * {", want: FormSelect},
+		{name: "ask", src: "BASE <http://example.com/> ASK {}", want: FormAsk},
+		{name: "construct", src: "# comment\nCONSTRUCT", want: FormConstruct},
+		{name: "describe", src: "DESCRIBE <a>", want: FormDescribe},
+		{name: "update", src: "WITH <g> DELETE { ?s ?p ?o } WHERE { ?s ?p ?o }", want: FormUpdate},
+		{name: "empty", src: "", want: FormUpdate},
+		{name: "unknown", src: "FOO", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectForm(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DetectForm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DetectForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
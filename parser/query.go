@@ -0,0 +1,333 @@
+package parser
+
+import (
+	"strconv"
+)
+
+func (p *parser) parseQuery(pro Prologue) *Query {
+	q := &Query{base: base{pos: p.tok().pos}, Prologue: pro}
+	q.Form = queryForm(p.tok())
+	switch q.Form {
+	case FormSelect:
+		p.parseSelectClause(q)
+		p.parseDataset(q)
+		q.Where = p.parseWhereClause()
+	case FormConstruct:
+		p.next()
+		if p.punct("{") {
+			q.Template = p.parseTriplesTemplate()
+			p.parseDataset(q)
+			q.Where = p.parseWhereClause()
+			break
+		}
+		p.parseDataset(q)
+		p.expectKeyword("WHERE")
+		pos := p.tok().pos
+		q.Template = p.parseTriplesTemplate()
+		q.Where = &GroupPattern{base: base{pos: pos}}
+		for _, t := range q.Template {
+			q.Where.Elements = append(q.Where.Elements, t)
+		}
+	case FormDescribe:
+		p.next()
+		if p.acceptPunct("*") {
+			q.All = true
+		} else {
+			for p.isVarOrIRI() {
+				q.Describe = append(q.Describe, p.parseVarOrIRI())
+			}
+			if len(q.Describe) == 0 {
+				p.unexpected("variable, IRI or \"*\"")
+			}
+		}
+		p.parseDataset(q)
+		if p.keyword("WHERE") || p.punct("{") {
+			q.Where = p.parseWhereClause()
+		}
+	case FormAsk:
+		p.next()
+		p.parseDataset(q)
+		q.Where = p.parseWhereClause()
+	default:
+		p.unexpected("SELECT, CONSTRUCT, DESCRIBE or ASK")
+	}
+	p.parseSolutionModifier(q)
+	if p.keyword("VALUES") {
+		q.Values = p.parseValues()
+	}
+	p.expectEOF()
+	return q
+}
+
+func (p *parser) parseSelectClause(q *Query) {
+	q.Form = FormSelect
+	p.expectKeyword("SELECT")
+	switch {
+	case p.acceptKeyword("DISTINCT"):
+		q.Distinct = true
+	case p.acceptKeyword("REDUCED"):
+		q.Reduced = true
+	}
+	if p.acceptPunct("*") {
+		q.All = true
+		return
+	}
+	for {
+		switch {
+		case p.tok().kind == tokVar:
+			v := p.parseVar()
+			q.Projection = append(q.Projection, &Projection{base: v.base, Var: v})
+			continue
+		case p.punct("("):
+			pos := p.next().pos
+			e := p.parseExpression()
+			p.expectKeyword("AS")
+			v := p.parseVar()
+			p.expectPunct(")")
+			q.Projection = append(q.Projection, &Projection{base: base{pos: pos}, Expr: e, Var: v})
+			continue
+		}
+		if len(q.Projection) == 0 {
+			p.unexpected("variable, \"(\" or \"*\"")
+		}
+		return
+	}
+}
+
+func (p *parser) parseDataset(q *Query) {
+	for p.keyword("FROM") {
+		pos := p.next().pos
+		named := p.acceptKeyword("NAMED")
+		q.Dataset = append(q.Dataset, &DatasetClause{base: base{pos: pos}, Named: named, IRI: p.parseIRI()})
+	}
+}
+
+func (p *parser) parseWhereClause() *GroupPattern {
+	p.acceptKeyword("WHERE")
+	return p.parseGroupGraphPattern()
+}
+
+func (p *parser) parseSubSelect() *Query {
+	q := &Query{base: base{pos: p.tok().pos}}
+	p.parseSelectClause(q)
+	q.Where = p.parseWhereClause()
+	p.parseSolutionModifier(q)
+	if p.keyword("VALUES") {
+		q.Values = p.parseValues()
+	}
+	return q
+}
+
+// nolint: gocyclo
+func (p *parser) parseSolutionModifier(q *Query) {
+	if p.acceptKeyword("GROUP") {
+		p.expectKeyword("BY")
+		for {
+			pos := p.tok().pos
+			switch {
+			case p.tok().kind == tokVar:
+				v := p.parseVar()
+				q.GroupBy = append(q.GroupBy, &Projection{base: v.base, Var: v})
+				continue
+			case p.punct("("):
+				p.next()
+				c := &Projection{base: base{pos: pos}, Expr: p.parseExpression()}
+				if p.acceptKeyword("AS") {
+					c.Var = p.parseVar()
+				}
+				p.expectPunct(")")
+				q.GroupBy = append(q.GroupBy, c)
+				continue
+			case p.isCall():
+				q.GroupBy = append(q.GroupBy, &Projection{base: base{pos: pos}, Expr: p.parsePrimaryExpression()})
+				continue
+			}
+			if len(q.GroupBy) == 0 {
+				p.unexpected("group condition")
+			}
+			break
+		}
+	}
+	if p.acceptKeyword("HAVING") {
+		q.Having = append(q.Having, p.parseConstraint())
+		for p.punct("(") || p.isCall() {
+			q.Having = append(q.Having, p.parseConstraint())
+		}
+	}
+	if p.acceptKeyword("ORDER") {
+		p.expectKeyword("BY")
+		for {
+			pos := p.tok().pos
+			switch {
+			case p.keyword("ASC") || p.keyword("DESC"):
+				desc := p.keyword("DESC")
+				p.next()
+				p.expectPunct("(")
+				e := p.parseExpression()
+				p.expectPunct(")")
+				q.OrderBy = append(q.OrderBy, &OrderCondition{base: base{pos: pos}, Desc: desc, Expr: e})
+				continue
+			case p.tok().kind == tokVar:
+				q.OrderBy = append(q.OrderBy, &OrderCondition{base: base{pos: pos}, Expr: p.parseVar()})
+				continue
+			case p.punct("(") || p.isCall():
+				q.OrderBy = append(q.OrderBy, &OrderCondition{base: base{pos: pos}, Expr: p.parseConstraint()})
+				continue
+			}
+			if len(q.OrderBy) == 0 {
+				p.unexpected("order condition")
+			}
+			break
+		}
+	}
+	for i := 0; i < 2; i++ {
+		switch {
+		case q.Limit == nil && p.acceptKeyword("LIMIT"):
+			q.Limit = p.parseInteger()
+		case q.Offset == nil && p.acceptKeyword("OFFSET"):
+			q.Offset = p.parseInteger()
+		}
+	}
+}
+
+func (p *parser) parseInteger() *int64 {
+	t := p.tok()
+	if t.kind != tokInteger {
+		p.unexpected("integer")
+	}
+	p.next()
+	n, err := strconv.ParseInt(t.text, 10, 64)
+	if err != nil {
+		p.failf(t.pos, "integer %s is out of range", t.text)
+	}
+	return &n
+}
+
+// parseGroupGraphPattern parses `{ ... }`.
+// nolint: gocyclo
+func (p *parser) parseGroupGraphPattern() *GroupPattern {
+	g := &GroupPattern{base: base{pos: p.expectPunct("{").pos}}
+	if p.keyword("SELECT") {
+		g.SubSelect = p.parseSubSelect()
+		p.expectPunct("}")
+		return g
+	}
+	for !p.acceptPunct("}") {
+		pos := p.tok().pos
+		var n Node
+		switch {
+		case p.punct("{"):
+			n = p.parseGroupOrUnionPattern()
+		case p.acceptKeyword("OPTIONAL"):
+			n = &OptionalPattern{base: base{pos: pos}, Pattern: p.parseGroupGraphPattern()}
+		case p.acceptKeyword("MINUS"):
+			n = &MinusPattern{base: base{pos: pos}, Pattern: p.parseGroupGraphPattern()}
+		case p.acceptKeyword("GRAPH"):
+			name := p.parseVarOrIRI()
+			n = &GraphPattern{base: base{pos: pos}, Name: name, Pattern: p.parseGroupGraphPattern()}
+		case p.acceptKeyword("SERVICE"):
+			silent := p.acceptKeyword("SILENT")
+			name := p.parseVarOrIRI()
+			n = &ServicePattern{base: base{pos: pos}, Silent: silent, Name: name, Pattern: p.parseGroupGraphPattern()}
+		case p.acceptKeyword("FILTER"):
+			n = &Filter{base: base{pos: pos}, Expr: p.parseConstraint()}
+		case p.acceptKeyword("BIND"):
+			p.expectPunct("(")
+			e := p.parseExpression()
+			p.expectKeyword("AS")
+			v := p.parseVar()
+			p.expectPunct(")")
+			n = &Bind{base: base{pos: pos}, Expr: e, Var: v}
+		case p.keyword("VALUES"):
+			n = p.parseValues()
+		default:
+			g.Elements = append(g.Elements, p.parseTriplesSameSubject(true))
+			if !p.acceptPunct(".") && !p.punct("}") && !p.isPatternStart() {
+				p.unexpected("\".\" or \"}\"")
+			}
+			continue
+		}
+		g.Elements = append(g.Elements, n)
+		p.acceptPunct(".")
+	}
+	return g
+}
+
+// isPatternStart reports whether the current token starts a pattern other than triples.
+func (p *parser) isPatternStart() bool {
+	if p.punct("{") {
+		return true
+	}
+	for _, k := range []string{"OPTIONAL", "MINUS", "GRAPH", "SERVICE", "FILTER", "BIND", "VALUES"} {
+		if p.keyword(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseGroupOrUnionPattern() Node {
+	g := p.parseGroupGraphPattern()
+	if !p.keyword("UNION") {
+		return g
+	}
+	u := &UnionPattern{base: g.base, Patterns: []*GroupPattern{g}}
+	for p.acceptKeyword("UNION") {
+		u.Patterns = append(u.Patterns, p.parseGroupGraphPattern())
+	}
+	return u
+}
+
+// parseValues parses `VALUES` and the data block.
+// nolint: gocyclo
+func (p *parser) parseValues() *Values {
+	v := &Values{base: base{pos: p.expectKeyword("VALUES").pos}}
+	if p.tok().kind == tokVar {
+		v.Vars = []*Var{p.parseVar()}
+		p.expectPunct("{")
+		for !p.acceptPunct("}") {
+			v.Rows = append(v.Rows, []Node{p.parseDataBlockValue()})
+		}
+		return v
+	}
+	if p.isNil() {
+		p.next()
+		p.next()
+	} else {
+		p.expectPunct("(")
+		for !p.acceptPunct(")") {
+			v.Vars = append(v.Vars, p.parseVar())
+		}
+	}
+	p.expectPunct("{")
+	for !p.acceptPunct("}") {
+		pos := p.tok().pos
+		var row []Node
+		if p.isNil() {
+			p.next()
+			p.next()
+		} else {
+			p.expectPunct("(")
+			for !p.acceptPunct(")") {
+				row = append(row, p.parseDataBlockValue())
+			}
+		}
+		if len(row) != len(v.Vars) {
+			p.failf(pos, "%d values for %d variables", len(row), len(v.Vars))
+		}
+		v.Rows = append(v.Rows, row)
+	}
+	return v
+}
+
+// parseDataBlockValue parses a value of VALUES. It returns nil for UNDEF.
+func (p *parser) parseDataBlockValue() Node {
+	t := p.tok()
+	switch {
+	case p.acceptKeyword("UNDEF"):
+		return nil
+	case t.kind == tokVar, t.kind == tokBNode, p.isNil(), p.isAnon():
+		p.unexpected("IRI, literal or UNDEF")
+	}
+	return p.parseVarOrTerm()
+}
@@ -0,0 +1,198 @@
+package parser
+
+// parseTriplesTemplate parses `{ triples }` without property paths.
+func (p *parser) parseTriplesTemplate() []*Triples {
+	p.expectPunct("{")
+	ts := p.parseTriplesList()
+	p.expectPunct("}")
+	return ts
+}
+
+// parseTriplesList parses triples without property paths separated by `.` until `}`
+// or `GRAPH` of quads.
+func (p *parser) parseTriplesList() []*Triples {
+	var ts []*Triples
+	for !p.punct("}") && !p.keyword("GRAPH") {
+		ts = append(ts, p.parseTriplesSameSubject(false))
+		if !p.acceptPunct(".") {
+			break
+		}
+	}
+	return ts
+}
+
+// parseTriplesSameSubject parses triples of the same subject. The predicates can be
+// property paths if paths is true.
+func (p *parser) parseTriplesSameSubject(paths bool) *Triples {
+	t := &Triples{base: base{pos: p.tok().pos}}
+	switch {
+	case p.punct("[") && !p.isAnon(), p.punct("(") && !p.isNil():
+		t.Subject = p.parseTriplesNode(paths)
+		if p.isVerbStart(paths) {
+			t.Properties = p.parsePropertyList(paths)
+		}
+	default:
+		t.Subject = p.parseVarOrTerm()
+		t.Properties = p.parsePropertyList(paths)
+	}
+	return t
+}
+
+func (p *parser) isVerbStart(paths bool) bool {
+	t := p.tok()
+	switch t.kind {
+	case tokVar, tokIRI, tokPName, tokPlaceholder:
+		return true
+	case tokWord:
+		return t.text == "a"
+	case tokPunct:
+		return paths && (t.text == "^" || t.text == "!" || t.text == "(")
+	}
+	return false
+}
+
+// parsePropertyList parses the non-empty property list.
+func (p *parser) parsePropertyList(paths bool) []*Property {
+	var props []*Property
+	for {
+		pos := p.tok().pos
+		verb := p.parseVerb(paths)
+		prop := &Property{base: base{pos: pos}, Verb: verb}
+		for {
+			prop.Objects = append(prop.Objects, p.parseGraphNode(paths))
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		props = append(props, prop)
+		if !p.punct(";") {
+			return props
+		}
+		for p.acceptPunct(";") {
+		}
+		if !p.isVerbStart(paths) {
+			return props
+		}
+	}
+}
+
+func (p *parser) parseVerb(paths bool) Node {
+	t := p.tok()
+	switch {
+	case t.kind == tokVar:
+		return p.parseVar()
+	case paths:
+		return p.parsePath()
+	case t.kind == tokWord && t.text == "a":
+		p.next()
+		return &IRI{base: base{pos: t.pos}, Value: rdfType}
+	case t.kind == tokIRI, t.kind == tokPName, t.kind == tokPlaceholder:
+		return p.parseIRI()
+	}
+	p.unexpected("predicate")
+	return nil
+}
+
+func (p *parser) parseGraphNode(paths bool) Node {
+	if p.punct("[") && !p.isAnon() || p.punct("(") && !p.isNil() {
+		return p.parseTriplesNode(paths)
+	}
+	return p.parseVarOrTerm()
+}
+
+// parseTriplesNode parses a collection or a blank node property list.
+func (p *parser) parseTriplesNode(paths bool) Node {
+	t := p.next()
+	if t.text == "(" {
+		c := &Collection{base: base{pos: t.pos}}
+		for !p.acceptPunct(")") {
+			c.Items = append(c.Items, p.parseGraphNode(paths))
+		}
+		return c
+	}
+	b := &BlankNodePropertyList{base: base{pos: t.pos}, Properties: p.parsePropertyList(paths)}
+	p.expectPunct("]")
+	return b
+}
+
+func (p *parser) parsePath() Node {
+	pos := p.tok().pos
+	paths := []Node{p.parsePathSequence()}
+	for p.acceptPunct("|") {
+		paths = append(paths, p.parsePathSequence())
+	}
+	if len(paths) == 1 {
+		return paths[0]
+	}
+	return &PathAlternative{base: base{pos: pos}, Paths: paths}
+}
+
+func (p *parser) parsePathSequence() Node {
+	pos := p.tok().pos
+	paths := []Node{p.parsePathEltOrInverse()}
+	for p.acceptPunct("/") {
+		paths = append(paths, p.parsePathEltOrInverse())
+	}
+	if len(paths) == 1 {
+		return paths[0]
+	}
+	return &PathSequence{base: base{pos: pos}, Paths: paths}
+}
+
+func (p *parser) parsePathEltOrInverse() Node {
+	pos := p.tok().pos
+	if p.acceptPunct("^") {
+		return &PathInverse{base: base{pos: pos}, Path: p.parsePathElt()}
+	}
+	return p.parsePathElt()
+}
+
+func (p *parser) parsePathElt() Node {
+	pos := p.tok().pos
+	path := p.parsePathPrimary()
+	if p.punct("?") || p.punct("*") || p.punct("+") {
+		return &PathMod{base: base{pos: pos}, Path: path, Mod: p.next().text}
+	}
+	return path
+}
+
+func (p *parser) parsePathPrimary() Node {
+	t := p.tok()
+	switch {
+	case p.acceptPunct("!"):
+		n := &PathNegated{base: base{pos: t.pos}}
+		if !p.acceptPunct("(") {
+			n.Paths = []Node{p.parsePathOneInPropertySet()}
+			return n
+		}
+		for !p.acceptPunct(")") {
+			if len(n.Paths) > 0 {
+				p.expectPunct("|")
+			}
+			n.Paths = append(n.Paths, p.parsePathOneInPropertySet())
+		}
+		return n
+	case p.acceptPunct("("):
+		path := p.parsePath()
+		p.expectPunct(")")
+		return path
+	}
+	return p.parseIRIOrA()
+}
+
+func (p *parser) parsePathOneInPropertySet() Node {
+	t := p.tok()
+	if p.acceptPunct("^") {
+		return &PathInverse{base: base{pos: t.pos}, Path: p.parseIRIOrA()}
+	}
+	return p.parseIRIOrA()
+}
+
+func (p *parser) parseIRIOrA() Node {
+	t := p.tok()
+	if t.kind == tokWord && t.text == "a" {
+		p.next()
+		return &IRI{base: base{pos: t.pos}, Value: rdfType}
+	}
+	return p.parseIRI()
+}
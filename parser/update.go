@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"strings"
+)
+
+func (p *parser) parseUpdate(pro Prologue) *Update {
+	u := &Update{base: base{pos: p.tok().pos}, Prologue: pro}
+	for p.tok().kind != tokEOF {
+		u.Operations = append(u.Operations, p.parseOperation())
+		if !p.acceptPunct(";") {
+			break
+		}
+		p.parsePrologue(&u.Prologue)
+	}
+	p.expectEOF()
+	return u
+}
+
+// nolint: gocyclo
+func (p *parser) parseOperation() Node {
+	t := p.tok()
+	pos := base{pos: t.pos}
+	switch {
+	case p.acceptKeyword("LOAD"):
+		l := &Load{base: pos, Silent: p.acceptKeyword("SILENT"), Source: p.parseIRI()}
+		if p.acceptKeyword("INTO") {
+			p.expectKeyword("GRAPH")
+			l.Into = p.parseIRI()
+		}
+		return l
+	case p.acceptKeyword("CLEAR"), p.acceptKeyword("DROP"):
+		g := &GraphManagement{base: pos, Op: strings.ToUpper(t.text), Silent: p.acceptKeyword("SILENT")}
+		if k := p.tok(); p.acceptKeyword("DEFAULT") || p.acceptKeyword("NAMED") || p.acceptKeyword("ALL") {
+			g.Target = &GraphKeyword{base: base{pos: k.pos}, Name: strings.ToUpper(k.text)}
+			return g
+		}
+		p.expectKeyword("GRAPH")
+		g.Target = p.parseIRI()
+		return g
+	case p.acceptKeyword("CREATE"):
+		g := &GraphManagement{base: pos, Op: "CREATE", Silent: p.acceptKeyword("SILENT")}
+		p.expectKeyword("GRAPH")
+		g.Target = p.parseIRI()
+		return g
+	case p.acceptKeyword("ADD"), p.acceptKeyword("MOVE"), p.acceptKeyword("COPY"):
+		g := &GraphTransfer{base: pos, Op: strings.ToUpper(t.text), Silent: p.acceptKeyword("SILENT")}
+		g.From = p.parseGraphOrDefault()
+		p.expectKeyword("TO")
+		g.To = p.parseGraphOrDefault()
+		return g
+	case p.keyword("INSERT") && isKeyword(p.peek(1), "DATA"):
+		p.next()
+		p.next()
+		d := &InsertData{base: pos, Quads: p.parseQuads()}
+		p.checkData(d.Quads, "INSERT DATA", true)
+		return d
+	case p.keyword("DELETE") && isKeyword(p.peek(1), "DATA"):
+		p.next()
+		p.next()
+		d := &DeleteData{base: pos, Quads: p.parseQuads()}
+		p.checkData(d.Quads, "DELETE DATA", false)
+		return d
+	case p.keyword("DELETE") && isKeyword(p.peek(1), "WHERE"):
+		p.next()
+		p.next()
+		d := &DeleteWhere{base: pos, Quads: p.parseQuads()}
+		p.checkBlankNodes(d.Quads, "DELETE WHERE")
+		return d
+	case p.keyword("WITH"), p.keyword("DELETE"), p.keyword("INSERT"):
+		return p.parseModify()
+	}
+	p.unexpected("update operation")
+	return nil
+}
+
+func (p *parser) parseGraphOrDefault() Node {
+	if k := p.tok(); p.acceptKeyword("DEFAULT") {
+		return &GraphKeyword{base: base{pos: k.pos}, Name: "DEFAULT"}
+	}
+	p.acceptKeyword("GRAPH")
+	return p.parseIRI()
+}
+
+func (p *parser) parseModify() Node {
+	m := &Modify{base: base{pos: p.tok().pos}}
+	if p.acceptKeyword("WITH") {
+		m.With = p.parseIRI()
+	}
+	if p.acceptKeyword("DELETE") {
+		m.Delete = p.parseQuads()
+		p.checkBlankNodes(m.Delete, "DELETE")
+	}
+	if p.acceptKeyword("INSERT") {
+		m.Insert = p.parseQuads()
+	}
+	if m.Delete == nil && m.Insert == nil {
+		p.unexpected("DELETE or INSERT")
+	}
+	for p.keyword("USING") {
+		pos := p.next().pos
+		named := p.acceptKeyword("NAMED")
+		m.Using = append(m.Using, &DatasetClause{base: base{pos: pos}, Named: named, IRI: p.parseIRI()})
+	}
+	p.expectKeyword("WHERE")
+	m.Where = p.parseGroupGraphPattern()
+	return m
+}
+
+// parseQuads parses `{ quads }`. The quads are not nil even if it's empty.
+func (p *parser) parseQuads() []Node {
+	p.expectPunct("{")
+	quads := []Node{}
+	for !p.acceptPunct("}") {
+		if !p.keyword("GRAPH") {
+			for _, t := range p.parseTriplesList() {
+				quads = append(quads, t)
+			}
+			if !p.punct("}") && !p.keyword("GRAPH") {
+				p.unexpected("\".\" or \"}\"")
+			}
+			continue
+		}
+		g := &GraphQuads{base: base{pos: p.next().pos}, Name: p.parseVarOrIRI()}
+		g.Triples = p.parseTriplesTemplate()
+		quads = append(quads, g)
+		p.acceptPunct(".")
+	}
+	return quads
+}
+
+// checkData fails if the data have variables or blank nodes which are not allowed.
+func (p *parser) checkData(quads []Node, operation string, blankNodes bool) {
+	for _, q := range quads {
+		Inspect(q, func(n Node) bool {
+			if _, ok := n.(*Var); ok {
+				p.failf(n.Pos(), "variables are not allowed in %s", operation)
+			}
+			return true
+		})
+	}
+	if !blankNodes {
+		p.checkBlankNodes(quads, operation)
+	}
+}
+
+func (p *parser) checkBlankNodes(quads []Node, operation string) {
+	for _, q := range quads {
+		Inspect(q, func(n Node) bool {
+			switch n.(type) {
+			case *BlankNode, *BlankNodePropertyList:
+				p.failf(n.Pos(), "blank nodes are not allowed in %s", operation)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUpdate(t *testing.T) {
+	got, err := ParseUpdate(`PREFIX ex: <http://example.com/>
+INSERT DATA { ex:a ex:b "c" . GRAPH ex:g { ex:a ex:b 1 } } ;
+PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+WITH ex:g DELETE { ?s foaf:name ?o } INSERT { ?s foaf:nick ?o } USING ex:h USING NAMED ex:i WHERE { ?s foaf:name ?o } ;
+DELETE WHERE { ?s ?p ?o } ;
+LOAD SILENT <http://example.com/data> INTO GRAPH ex:g ;
+CLEAR SILENT ALL ; DROP GRAPH ex:g ; CREATE GRAPH ex:h ;
+COPY DEFAULT TO ex:g ; MOVE SILENT GRAPH ex:g TO DEFAULT ; ADD ex:g TO ex:h ;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Prefixes) != 2 {
+		t.Errorf("Prefixes = %v, want 2 prefixes", got.Prefixes)
+	}
+	var ops []string
+	for _, o := range got.Operations {
+		ops = append(ops, reflect.TypeOf(o).Elem().Name())
+	}
+	want := []string{
+		"InsertData", "Modify", "DeleteWhere", "Load", "GraphManagement", "GraphManagement",
+		"GraphManagement", "GraphTransfer", "GraphTransfer", "GraphTransfer",
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("Operations = %v, want %v", ops, want)
+	}
+
+	m := got.Operations[1].(*Modify)
+	if len(m.Delete) != 1 || len(m.Insert) != 1 || len(m.Using) != 2 || !m.Using[1].Named || m.Where == nil {
+		t.Errorf("Modify = %+v", m)
+	}
+	clear := got.Operations[4].(*GraphManagement)
+	if clear.Op != "CLEAR" || !clear.Silent || clear.Target.(*GraphKeyword).Name != "ALL" {
+		t.Errorf("GraphManagement = %+v", clear)
+	}
+	move := got.Operations[8].(*GraphTransfer)
+	if move.Op != "MOVE" || !move.Silent || move.To.(*GraphKeyword).Name != "DEFAULT" {
+		t.Errorf("GraphTransfer = %+v", move)
+	}
+}
+
+func TestParseUpdate_empty(t *testing.T) {
+	got, err := ParseUpdate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Operations) != 0 {
+		t.Errorf("Operations = %v, want empty", got.Operations)
+	}
+	if _, err := ParseUpdate("INSERT {} WHERE {}"); err != nil {
+		t.Errorf("ParseUpdate() of empty templates error = %v", err)
+	}
+}
+
+// nolint: scopelint
+func TestParseUpdate_error(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "variable in INSERT DATA",
+			src:  "INSERT DATA { <a> <b> ?c }",
+			want: "syntax error at line 1 col 23: variables are not allowed in INSERT DATA",
+		},
+		{
+			name: "blank node in DELETE DATA",
+			src:  "DELETE DATA { GRAPH <g> { _:a <b> <c> } }",
+			want: "syntax error at line 1 col 27: blank nodes are not allowed in DELETE DATA",
+		},
+		{
+			name: "blank node in DELETE",
+			src:  "DELETE { [] <b> ?c } WHERE { ?s <b> ?c }",
+			want: "syntax error at line 1 col 10: blank nodes are not allowed in DELETE",
+		},
+		{
+			name: "modify without WHERE",
+			src:  "DELETE { ?s ?p ?o }",
+			want: "syntax error at line 1 col 20: unexpected end of input, expected WHERE",
+		},
+		{
+			name: "missing separator",
+			src:  "CLEAR ALL DROP ALL",
+			want: `syntax error at line 1 col 11: unexpected "DROP", expected end of input`,
+		},
+		{
+			name: "query",
+			src:  "SELECT * {}",
+			want: `syntax error at line 1 col 1: unexpected "SELECT", expected update operation`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseUpdate(tt.src)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ParseUpdate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package parser
+
+// Inspect traverses the syntax tree in depth-first order. It calls f(node) and
+// then the children of the node if f returns true. Nil nodes such as UNDEF are skipped.
+// nolint: gocyclo
+func Inspect(node Node, f func(Node) bool) {
+	if isNilNode(node) || !f(node) {
+		return
+	}
+	walk := func(ns ...Node) {
+		for _, n := range ns {
+			Inspect(n, f)
+		}
+	}
+	switch n := node.(type) {
+	case *Query:
+		for _, d := range n.Prefixes {
+			walk(d)
+		}
+		for _, pr := range n.Projection {
+			walk(pr)
+		}
+		for _, t := range n.Template {
+			walk(t)
+		}
+		walk(n.Describe...)
+		for _, d := range n.Dataset {
+			walk(d)
+		}
+		walk(n.Where)
+		for _, g := range n.GroupBy {
+			walk(g)
+		}
+		walk(n.Having...)
+		for _, o := range n.OrderBy {
+			walk(o)
+		}
+		walk(n.Values)
+	case *Projection:
+		walk(n.Expr, n.Var)
+	case *DatasetClause:
+		walk(n.IRI)
+	case *OrderCondition:
+		walk(n.Expr)
+	case *Literal:
+		walk(n.Datatype)
+	case *Collection:
+		walk(n.Items...)
+	case *BlankNodePropertyList:
+		for _, pr := range n.Properties {
+			walk(pr)
+		}
+	case *Triples:
+		walk(n.Subject)
+		for _, pr := range n.Properties {
+			walk(pr)
+		}
+	case *Property:
+		walk(n.Verb)
+		walk(n.Objects...)
+	case *PathAlternative:
+		walk(n.Paths...)
+	case *PathSequence:
+		walk(n.Paths...)
+	case *PathInverse:
+		walk(n.Path)
+	case *PathMod:
+		walk(n.Path)
+	case *PathNegated:
+		walk(n.Paths...)
+	case *GroupPattern:
+		walk(n.Elements...)
+		walk(n.SubSelect)
+	case *OptionalPattern:
+		walk(n.Pattern)
+	case *UnionPattern:
+		for _, g := range n.Patterns {
+			walk(g)
+		}
+	case *MinusPattern:
+		walk(n.Pattern)
+	case *GraphPattern:
+		walk(n.Name, n.Pattern)
+	case *ServicePattern:
+		walk(n.Name, n.Pattern)
+	case *Filter:
+		walk(n.Expr)
+	case *Bind:
+		walk(n.Expr, n.Var)
+	case *Values:
+		for _, v := range n.Vars {
+			walk(v)
+		}
+		for _, row := range n.Rows {
+			walk(row...)
+		}
+	case *BinaryExpr:
+		walk(n.X, n.Y)
+	case *UnaryExpr:
+		walk(n.X)
+	case *InExpr:
+		walk(n.X)
+		walk(n.List...)
+	case *CallExpr:
+		walk(n.Function)
+		walk(n.Args...)
+	case *ExistsExpr:
+		walk(n.Pattern)
+	case *Update:
+		for _, d := range n.Prefixes {
+			walk(d)
+		}
+		walk(n.Operations...)
+	case *Load:
+		walk(n.Source, n.Into)
+	case *GraphManagement:
+		walk(n.Target)
+	case *GraphTransfer:
+		walk(n.From, n.To)
+	case *GraphQuads:
+		walk(n.Name)
+		for _, t := range n.Triples {
+			walk(t)
+		}
+	case *InsertData:
+		walk(n.Quads...)
+	case *DeleteData:
+		walk(n.Quads...)
+	case *DeleteWhere:
+		walk(n.Quads...)
+	case *Modify:
+		walk(n.With)
+		walk(n.Delete...)
+		walk(n.Insert...)
+		for _, u := range n.Using {
+			walk(u)
+		}
+		walk(n.Where)
+	}
+}
+
+// isNilNode reports whether the node is nil including typed nil pointers.
+func isNilNode(node Node) bool {
+	switch n := node.(type) {
+	case nil:
+		return true
+	case *Var:
+		return n == nil
+	case *GroupPattern:
+		return n == nil
+	case *Query:
+		return n == nil
+	case *Values:
+		return n == nil
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	n, err := Parse(`SELECT ?s (COUNT(?o) AS ?n) WHERE { ?s <p> ?o OPTIONAL { ?o <q> $1 } FILTER (?o != @name) } VALUES ?s { UNDEF }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	Inspect(n, func(n Node) bool {
+		switch n := n.(type) {
+		case *Var:
+			got = append(got, "?"+n.Name)
+		case *Placeholder:
+			got = append(got, n.Name)
+		case *Filter:
+			// Skip the children.
+			return false
+		}
+		return true
+	})
+	want := []string{"?s", "?o", "?n", "?s", "?o", "?o", "$1", "?s"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Inspect() visited %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,22 @@
+package sparql
+
+import (
+	"errors"
+)
+
+// errResultNotSupported is returned because SPARQL Update reports neither
+// inserted IDs nor the number of affected triples.
+var errResultNotSupported = errors.New("not supported by SPARQL Update")
+
+// Result implements `driver.Result` for SPARQL Update.
+type Result struct{}
+
+// LastInsertId is not supported. Always returns an error.
+func (Result) LastInsertId() (int64, error) {
+	return 0, errResultNotSupported
+}
+
+// RowsAffected is not supported. Always returns an error.
+func (Result) RowsAffected() (int64, error) {
+	return 0, errResultNotSupported
+}
@@ -0,0 +1,17 @@
+package sparql
+
+import (
+	"testing"
+)
+
+func TestResult_LastInsertId(t *testing.T) {
+	if _, err := (Result{}).LastInsertId(); err != errResultNotSupported {
+		t.Errorf("Result.LastInsertId() error = %v", err)
+	}
+}
+
+func TestResult_RowsAffected(t *testing.T) {
+	if _, err := (Result{}).RowsAffected(); err != errResultNotSupported {
+		t.Errorf("Result.RowsAffected() error = %v", err)
+	}
+}
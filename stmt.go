@@ -3,6 +3,7 @@ package sparql
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
 
 	"github.com/garsue/sparql/client"
 )
@@ -14,29 +15,67 @@ type Stmt struct {
 
 // QueryContext queries to a SPARQL source.
 func (s *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := s.checkArgs(args); err != nil {
+		return nil, err
+	}
 	result, err := s.Statement.Query(ctx, argsToParams(args)...)
 	if err != nil {
 		return nil, err
 	}
+	ask, err := isAsk(s.Form(), result)
+	if err != nil {
+		_ = result.Close()
+		return nil, err
+	}
 
 	return &Rows{
 		queryResult: result,
+		ask:         ask,
 	}, nil
 }
 
+// ExecContext sends a SPARQL Update request to a SPARQL source.
+func (s *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := s.checkArgs(args); err != nil {
+		return nil, err
+	}
+	if err := s.Statement.Update(ctx, argsToParams(args)...); err != nil {
+		return nil, err
+	}
+	return Result{}, nil
+}
+
 // Close closes the statement. Actually do nothing.
 func (s *Stmt) Close() error {
 	return nil
 }
 
-// NumInput is not supported. Always return -1.
+// NumInput returns the number of placeholder parameters.
+// It returns -1 if the statement mixes `$n` and `@name` placeholders.
 func (s *Stmt) NumInput() int {
-	return -1
+	return s.Statement.NumInput()
+}
+
+// checkArgs fails fast before sending a request if the number of arguments is wrong.
+func (s *Stmt) checkArgs(args []driver.NamedValue) error {
+	if n := s.NumInput(); n >= 0 && len(args) != n {
+		return fmt.Errorf("sparql: expected %d arguments, got %d", n, len(args))
+	}
+	return nil
 }
 
-// Exec is not supported. DO NOT USE.
-func (*Stmt) Exec(args []driver.Value) (driver.Result, error) {
-	panic("not supported")
+// Exec sends a SPARQL Update request to a SPARQL source.
+//
+// Deprecated: Use ExecContext instead.
+func (s *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, v := range args {
+		named = append(named, driver.NamedValue{
+			Ordinal: i + 1,
+			Value:   v,
+		})
+	}
+	return s.ExecContext(context.Background(), named)
 }
 
 // Query queries to the endpoint.
@@ -3,6 +3,7 @@ package sparql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,7 +11,7 @@ import (
 	"github.com/garsue/sparql/client"
 )
 
-//noinspection ALL
+// noinspection ALL
 func TestStmt_QueryContext(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		db := sql.OpenDB(NewConnector("foo"))
@@ -43,6 +44,38 @@ func TestStmt_QueryContext(t *testing.T) {
 	})
 }
 
+func TestStmt_ExecContext(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		db := sql.OpenDB(NewConnector("foo"))
+		defer db.Close()
+		stmt, err := db.Prepare("")
+		if err != nil {
+			t.Errorf("Conn.Prepare() error = %v", err)
+			return
+		}
+		if _, err := stmt.ExecContext(context.Background()); err == nil {
+			t.Errorf("Stmt.ExecContext() error = %v", err)
+			return
+		}
+	})
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, h *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		db := sql.OpenDB(NewConnector(server.URL))
+		defer db.Close()
+		stmt, err := db.Prepare("")
+		if err != nil {
+			t.Errorf("Conn.Prepare() error = %v", err)
+			return
+		}
+		if _, err := stmt.ExecContext(context.Background()); err != nil {
+			t.Errorf("Stmt.ExecContext() error = %v", err)
+			return
+		}
+	})
+}
+
 func TestStmt_Close(t *testing.T) {
 	var s Stmt
 	if err := s.Close(); err != nil {
@@ -51,22 +84,61 @@ func TestStmt_Close(t *testing.T) {
 }
 
 func TestStmt_NumInput(t *testing.T) {
+	cli, err := client.New("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
 	s := Stmt{
-		Statement: &client.Statement{},
+		Statement: cli.Prepare("SELECT * WHERE { $1 ?p $2 }"),
 	}
-	if got := s.NumInput(); got != -1 {
-		t.Errorf("Stmt.NumInput() = %v, want -1", got)
+	if got := s.NumInput(); got != 2 {
+		t.Errorf("Stmt.NumInput() = %v, want 2", got)
+	}
+}
+
+func TestStmt_checkArgs(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, h *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	db := sql.OpenDB(NewConnector(server.URL))
+	defer db.Close()
+	stmt, err := db.Prepare("SELECT * WHERE { @s ?p ?o }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Query(); err == nil {
+		t.Errorf("Stmt.QueryContext() error = %v", err)
+	}
+	if _, err := db.Query("SELECT * WHERE { $1 ?p $2 }", 1); err == nil {
+		t.Errorf("Conn.QueryContext() error = %v", err)
+	}
+	if _, err := db.Exec("INSERT DATA { $1 ?p ?o }"); err == nil {
+		t.Errorf("Conn.ExecContext() error = %v", err)
+	}
+	if requested {
+		t.Error("request is sent with wrong arguments")
 	}
 }
 
 func TestStmt_Exec(t *testing.T) {
-	defer func() {
-		if err := recover(); err == nil {
-			t.Error("Expect panic")
-		}
-	}()
-	var s Stmt
-	_, _ = s.Exec(nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, h *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	cli, err := client.New(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Stmt{
+		Statement: cli.Prepare("INSERT DATA { <s> <p> $1 }"),
+	}
+	if _, err := s.Exec([]driver.Value{1}); err != nil {
+		t.Errorf("Stmt.Exec() error = %v", err)
+	}
 }
 
 func TestStmt_Query(t *testing.T) {